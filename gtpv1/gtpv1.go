@@ -0,0 +1,54 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package gtpv1 provides the values and connections shared across the
+// gtpv1/ie and gtpv1/message packages, such as the cause/mode/type value
+// enumerations carried inside IEs.
+package gtpv1
+
+// Cause values used by the MAPCause and RANAPCause IEs. These mirror the
+// MAP/RANAP cause codes referenced from a GTPv1 Cause-in-cause IE rather
+// than being GTP-specific.
+const (
+	MAPCauseUnknownSubscriber MAPCause = 0x01
+	MAPCauseSystemFailure     MAPCause = 0x22
+)
+
+// MAPCause is the value carried by the MAPCause and RANAPCause IEs.
+type MAPCause uint8
+
+// SelectionMode values used by the SelectionMode IE.
+const (
+	SelectionModeMSorNetworkProvidedAPNSubscribedVerified  SelectionMode = 0xf0
+	SelectionModeMSProvidedAPNSubscriptionNotVerified      SelectionMode = 0xf1
+	SelectionModeNetworkProvidedAPNSubscriptionNotVerified SelectionMode = 0xf2
+)
+
+// SelectionMode is the value carried by the SelectionMode IE.
+type SelectionMode uint8
+
+// APNRestriction values used by the APNRestriction IE.
+const (
+	APNRestrictionNoExistingContexts APNRestriction = 0x00
+	APNRestrictionPublic1            APNRestriction = 0x01
+	APNRestrictionPublic2            APNRestriction = 0x02
+	APNRestrictionPrivate1           APNRestriction = 0x03
+	APNRestrictionPrivate2           APNRestriction = 0x04
+)
+
+// APNRestriction is the value carried by the APNRestriction IE.
+type APNRestriction uint8
+
+// RATType values used by the RATType IE.
+const (
+	RatTypeReserved RATType = 0x00
+	RatTypeUTRAN    RATType = 0x01
+	RatTypeGERAN    RATType = 0x02
+	RatTypeWLAN     RATType = 0x03
+	RatTypeGAN      RATType = 0x04
+	RatTypeEUTRAN   RATType = 0x06
+)
+
+// RATType is the value carried by the RATType IE.
+type RATType uint8
@@ -0,0 +1,72 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"net"
+	"strings"
+)
+
+// PDP Type Organization/Number octets for EndUserAddress, one pair per IP
+// version.
+const (
+	pdpOrgNumberIPv4Org = 0xf1
+	pdpOrgNumberIPv4Num = 0x21
+	pdpOrgNumberIPv6Org = 0x00
+	pdpOrgNumberIPv6Num = 0x57
+)
+
+// NewIMSI creates an IMSI IE from its decimal digit string.
+func NewIMSI(imsi string) *IE {
+	return newFixed(TypeIMSI, encodeBCD(imsi, bcdOctets(len(imsi))))
+}
+
+// NewIMEISV creates an IMEISV IE from its decimal digit string.
+func NewIMEISV(imeisv string) *IE {
+	return newTLV(TypeIMEISV, encodeBCD(imeisv, bcdOctets(len(imeisv))))
+}
+
+// NewMSISDN creates an MSISDN IE. The number is encoded with the
+// international ISDN numbering plan indicator (0x91) the same way GSNs
+// advertise their own addresses.
+func NewMSISDN(msisdn string) *IE {
+	payload := append([]byte{0x91}, encodeBCD(msisdn, bcdOctets(len(msisdn)))...)
+	return newTLV(TypeMSISDN, payload)
+}
+
+// NewEndUserAddress creates an EndUserAddress IE for addr, which may be
+// either an IPv4 or an IPv6 address.
+func NewEndUserAddress(addr string) *IE {
+	ip := net.ParseIP(addr)
+	if ip4 := ip.To4(); ip4 != nil && !strings.Contains(addr, ":") {
+		payload := append([]byte{pdpOrgNumberIPv4Org, pdpOrgNumberIPv4Num}, ip4...)
+		return newTLV(TypeEndUserAddress, payload)
+	}
+
+	payload := append([]byte{pdpOrgNumberIPv6Org, pdpOrgNumberIPv6Num}, ip.To16()...)
+	return newTLV(TypeEndUserAddress, payload)
+}
+
+// NewGSNAddress creates a GSNAddress IE for addr, which may be either an
+// IPv4 or an IPv6 address.
+func NewGSNAddress(addr string) *IE {
+	ip := net.ParseIP(addr)
+	if ip4 := ip.To4(); ip4 != nil && !strings.Contains(addr, ":") {
+		return newTLV(TypeGSNAddress, append([]byte{}, ip4...))
+	}
+	return newTLV(TypeGSNAddress, append([]byte{}, ip.To16()...))
+}
+
+// NewAccessPointName creates an AccessPointName IE, encoding apn as a
+// sequence of DNS-style length-prefixed labels.
+func NewAccessPointName(apn string) *IE {
+	labels := strings.Split(apn, ".")
+	payload := make([]byte, 0, len(apn)+len(labels))
+	for _, label := range labels {
+		payload = append(payload, byte(len(label)))
+		payload = append(payload, label...)
+	}
+	return newTLV(TypeAccessPointName, payload)
+}
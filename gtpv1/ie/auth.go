@@ -0,0 +1,30 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+// NewAuthenticationTriplet creates an AuthenticationTriplet IE from a
+// 16-byte RAND, a 4-byte SRES and an 8-byte Kc.
+func NewAuthenticationTriplet(rand, sres, kc []byte) *IE {
+	payload := make([]byte, 0, len(rand)+len(sres)+len(kc))
+	payload = append(payload, rand...)
+	payload = append(payload, sres...)
+	payload = append(payload, kc...)
+	return newFixed(TypeAuthenticationTriplet, payload)
+}
+
+// NewAuthenticationQuintuplet creates an AuthenticationQuintuplet IE from a
+// 16-byte RAND and 16-byte CK/IK, plus variable-length XRES and AUTN, each
+// prefixed with their own 1-byte length.
+func NewAuthenticationQuintuplet(rand, xres, ck, ik, autn []byte) *IE {
+	payload := make([]byte, 0, len(rand)+1+len(xres)+len(ck)+len(ik)+1+len(autn))
+	payload = append(payload, rand...)
+	payload = append(payload, byte(len(xres)))
+	payload = append(payload, xres...)
+	payload = append(payload, ck...)
+	payload = append(payload, ik...)
+	payload = append(payload, byte(len(autn)))
+	payload = append(payload, autn...)
+	return newTLV(TypeAuthenticationQuintuplet, payload)
+}
@@ -0,0 +1,46 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+// encodeBCD packs digits into octets bytes of swapped-nibble BCD: the first
+// digit of each pair goes in the low nibble, the second in the high nibble.
+// A pair with no second digit is filled with 0xf.
+func encodeBCD(digits string, octets int) []byte {
+	b := make([]byte, octets)
+	for i := 0; i < octets; i++ {
+		lo, hi := byte(0x0f), byte(0x0f)
+		if idx := i * 2; idx < len(digits) {
+			lo = digits[idx] - '0'
+		}
+		if idx := i*2 + 1; idx < len(digits) {
+			hi = digits[idx] - '0'
+		}
+		b[i] = hi<<4 | lo
+	}
+	return b
+}
+
+// decodeBCD decodes a swapped-nibble BCD byte slice into its decimal digit
+// string, stopping at a 0xf filler nibble.
+func decodeBCD(b []byte) string {
+	digits := make([]byte, 0, len(b)*2)
+	for _, octet := range b {
+		lo, hi := octet&0x0f, octet>>4
+		if lo == 0x0f {
+			break
+		}
+		digits = append(digits, '0'+lo)
+		if hi == 0x0f {
+			break
+		}
+		digits = append(digits, '0'+hi)
+	}
+	return string(digits)
+}
+
+// bcdOctets returns the number of octets needed to hold n BCD digits.
+func bcdOctets(n int) int {
+	return (n + 1) / 2
+}
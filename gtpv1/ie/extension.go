@@ -0,0 +1,24 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "encoding/binary"
+
+// NewExtensionHeaderTypeList creates an ExtensionHeaderTypeList IE listing
+// the Extension Header Types a node supports, in the order given.
+func NewExtensionHeaderTypeList(types ...uint8) *IE {
+	payload := make([]byte, len(types))
+	copy(payload, types)
+	return newTLV(TypeExtensionHeaderTypeList, payload)
+}
+
+// NewPrivateExtension creates a PrivateExtension IE from a vendor-specific
+// extension ID and its opaque value.
+func NewPrivateExtension(extensionID uint16, value []byte) *IE {
+	payload := make([]byte, 2+len(value))
+	binary.BigEndian.PutUint16(payload[:2], extensionID)
+	copy(payload[2:], value)
+	return newTLV(TypePrivateExtension, payload)
+}
@@ -0,0 +1,290 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package ie handles the Information Elements (IEs) encoded in GTPv1
+// messages, as defined in 3GPP TS 29.060.
+package ie
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Type identifies the kind of an IE on the wire. Types below 0x80 are
+// encoded as a fixed-length value with no explicit length field; types at
+// or above 0x80 are encoded as a 1-byte type, a 2-byte big-endian length,
+// and a variable-length value (TLV), except the types in shortLengthTypes,
+// whose length field is a single byte.
+type Type uint8
+
+// IE type codes used by this package.
+const (
+	TypeIMSI                     Type = 0x02
+	TypePacketTMSI               Type = 0x05
+	TypeAuthenticationTriplet    Type = 0x09
+	TypeMAPCause                 Type = 0x0b
+	TypePTMSISignature           Type = 0x0c
+	TypeMSValidated              Type = 0x0d
+	TypeRecovery                 Type = 0x0e
+	TypeSelectionMode            Type = 0x0f
+	TypeTEIDDataI                Type = 0x10
+	TypeTEIDCPlane               Type = 0x11
+	TypeTEIDDataII               Type = 0x12
+	TypeTeardownInd              Type = 0x13
+	TypeNSAPI                    Type = 0x14
+	TypeRANAPCause               Type = 0x15
+	TypeChargingID               Type = 0x7f
+	TypeEndUserAddress           Type = 0x80
+	TypeAccessPointName          Type = 0x83
+	TypeGSNAddress               Type = 0x85
+	TypeMSISDN                   Type = 0x86
+	TypeAuthenticationQuintuplet Type = 0x88
+	TypeExtensionHeaderTypeList  Type = 0x8d
+	TypeCommonFlags              Type = 0x94
+	TypeAPNRestriction           Type = 0x95
+	TypeRATType                  Type = 0x97
+	TypeUserLocationInformation  Type = 0x98
+	TypeMSTimeZone               Type = 0x99
+	TypeIMEISV                   Type = 0x9a
+	TypeULITimestamp             Type = 0xd6
+	TypePrivateExtension         Type = 0xff
+)
+
+var typeNames = map[Type]string{
+	TypeIMSI:                     "IMSI",
+	TypePacketTMSI:               "PacketTMSI",
+	TypeAuthenticationTriplet:    "AuthenticationTriplet",
+	TypeMAPCause:                 "MAPCause",
+	TypePTMSISignature:           "PTMSISignature",
+	TypeMSValidated:              "MSValidated",
+	TypeRecovery:                 "Recovery",
+	TypeSelectionMode:            "SelectionMode",
+	TypeTEIDDataI:                "TEIDDataI",
+	TypeTEIDCPlane:               "TEIDCPlane",
+	TypeTEIDDataII:               "TEIDDataII",
+	TypeTeardownInd:              "TeardownInd",
+	TypeNSAPI:                    "NSAPI",
+	TypeRANAPCause:               "RANAPCause",
+	TypeChargingID:               "ChargingID",
+	TypeEndUserAddress:           "EndUserAddress",
+	TypeAccessPointName:          "AccessPointName",
+	TypeGSNAddress:               "GSNAddress",
+	TypeMSISDN:                   "MSISDN",
+	TypeAuthenticationQuintuplet: "AuthenticationQuintuplet",
+	TypeExtensionHeaderTypeList:  "ExtensionHeaderTypeList",
+	TypeCommonFlags:              "CommonFlags",
+	TypeAPNRestriction:           "APNRestriction",
+	TypeRATType:                  "RATType",
+	TypeUserLocationInformation:  "UserLocationInformation",
+	TypeMSTimeZone:               "MSTimeZone",
+	TypeIMEISV:                   "IMEISV",
+	TypeULITimestamp:             "ULITimestamp",
+	TypePrivateExtension:         "PrivateExtension",
+}
+
+// String implements the fmt.Stringer interface. Unrecognized types render
+// as their hex value so logs stay readable even for IEs this package
+// doesn't know about.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown(0x%02x)", uint8(t))
+}
+
+// shortLengthTypes holds the TLV types whose Length field is a single byte
+// rather than the usual 2-byte big-endian field. ExtensionHeaderTypeList is
+// the only such type in this package: 3GPP TS 29.060 predates it being
+// widened to 2 bytes like every other TLV added since.
+var shortLengthTypes = map[Type]bool{
+	TypeExtensionHeaderTypeList: true,
+}
+
+// fixedLengths holds the payload length of every IE type below 0x80, which
+// has no explicit length field on the wire.
+var fixedLengths = map[Type]int{
+	TypeIMSI:                  8,
+	TypePacketTMSI:            4,
+	TypeAuthenticationTriplet: 28,
+	TypeMAPCause:              1,
+	TypePTMSISignature:        3,
+	TypeMSValidated:           1,
+	TypeRecovery:              1,
+	TypeSelectionMode:         1,
+	TypeTEIDDataI:             4,
+	TypeTEIDCPlane:            4,
+	TypeTEIDDataII:            4,
+	TypeTeardownInd:           1,
+	TypeNSAPI:                 1,
+	TypeRANAPCause:            1,
+	TypeChargingID:            4,
+}
+
+// IE represents a single GTPv1 Information Element, either parsed from the
+// wire or built with one of the NewXxx constructors.
+type IE struct {
+	Type    Type
+	Length  uint16
+	Payload []byte
+}
+
+func newFixed(t Type, payload []byte) *IE {
+	return &IE{Type: t, Length: uint16(len(payload)), Payload: payload}
+}
+
+func newTLV(t Type, payload []byte) *IE {
+	return &IE{Type: t, Length: uint16(len(payload)), Payload: payload}
+}
+
+// MarshalLen returns the number of bytes Marshal/MarshalTo will produce for
+// this IE: 1 header byte plus the payload for fixed-length types, 2 header
+// bytes plus the payload for the short-length TLV types, or 3 header bytes
+// plus the payload for the rest.
+func (i *IE) MarshalLen() (int, error) {
+	switch {
+	case i.Type < 0x80:
+		return 1 + len(i.Payload), nil
+	case shortLengthTypes[i.Type]:
+		return 2 + len(i.Payload), nil
+	default:
+		return 3 + len(i.Payload), nil
+	}
+}
+
+// encodeInto writes the wire representation of i into b, which must be at
+// least as long as MarshalLen. It is shared by Marshal and MarshalTo so
+// that neither has to delegate to the other.
+func (i *IE) encodeInto(b []byte) int {
+	if i.Type < 0x80 {
+		b[0] = byte(i.Type)
+		n := copy(b[1:], i.Payload)
+		return 1 + n
+	}
+
+	if shortLengthTypes[i.Type] {
+		b[0] = byte(i.Type)
+		b[1] = byte(i.Length)
+		n := copy(b[2:], i.Payload)
+		return 2 + n
+	}
+
+	b[0] = byte(i.Type)
+	binary.BigEndian.PutUint16(b[1:3], i.Length)
+	n := copy(b[3:], i.Payload)
+	return 3 + n
+}
+
+// Marshal encodes the IE into its wire representation.
+func (i *IE) Marshal() ([]byte, error) {
+	l, err := i.MarshalLen()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, l)
+	n := i.encodeInto(b)
+
+	reportEncodeSuccess(i.Type.String(), n)
+	return b, nil
+}
+
+// MarshalTo encodes the IE into b, returning the number of bytes written.
+// It behaves like Marshal but lets callers reuse a buffer (e.g., the slice
+// of its caller's buffer that message.Message.MarshalTo passes down)
+// instead of allocating a new one on every call, which matters on the
+// steady-state encode path. The message layer keeps no buffer pool of its
+// own.
+func (i *IE) MarshalTo(b []byte) (int, error) {
+	l, err := i.MarshalLen()
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < l {
+		return 0, fmt.Errorf("ie: MarshalTo: buffer of %d bytes too small for %d-byte IE", len(b), l)
+	}
+
+	n := i.encodeInto(b)
+	reportEncodeSuccess(i.Type.String(), n)
+	return n, nil
+}
+
+// Parse decodes b into an IE. It never panics: malformed or truncated input
+// is reported as an error rather than a zero-value or out-of-bounds read.
+func Parse(b []byte) (*IE, error) {
+	if len(b) < 1 {
+		return nil, fmt.Errorf("ie: Parse: empty input")
+	}
+
+	typ := Type(b[0])
+
+	if typ < 0x80 {
+		l, ok := fixedLengths[typ]
+		if !ok {
+			reportUnknownType(typ.String())
+			return nil, fmt.Errorf("ie: Parse: unknown fixed-length IE type 0x%02x", uint8(typ))
+		}
+		if len(b) < 1+l {
+			reportMalformed(typ.String())
+			return nil, fmt.Errorf("ie: Parse: %s: need %d bytes, got %d", typ, 1+l, len(b))
+		}
+
+		payload := make([]byte, l)
+		copy(payload, b[1:1+l])
+		parsed := &IE{Type: typ, Length: uint16(l), Payload: payload}
+		checkValueRange(parsed)
+		reportDecodeSuccess(typ.String())
+		return parsed, nil
+	}
+
+	if shortLengthTypes[typ] {
+		if len(b) < 2 {
+			reportMalformed(typ.String())
+			return nil, fmt.Errorf("ie: Parse: %s: need at least 2 bytes for header, got %d", typ, len(b))
+		}
+
+		l := uint16(b[1])
+		if len(b) < 2+int(l) {
+			reportMalformed(typ.String())
+			return nil, fmt.Errorf("ie: Parse: %s: declared length %d exceeds remaining %d bytes", typ, l, len(b)-2)
+		}
+
+		payload := make([]byte, l)
+		copy(payload, b[2:2+int(l)])
+		parsed := &IE{Type: typ, Length: l, Payload: payload}
+		checkValueRange(parsed)
+		reportDecodeSuccess(typ.String())
+		return parsed, nil
+	}
+
+	if len(b) < 3 {
+		reportMalformed(typ.String())
+		return nil, fmt.Errorf("ie: Parse: %s: need at least 3 bytes for header, got %d", typ, len(b))
+	}
+
+	l := binary.BigEndian.Uint16(b[1:3])
+	if len(b) < 3+int(l) {
+		reportMalformed(typ.String())
+		return nil, fmt.Errorf("ie: Parse: %s: declared length %d exceeds remaining %d bytes", typ, l, len(b)-3)
+	}
+
+	payload := make([]byte, l)
+	copy(payload, b[3:3+int(l)])
+	parsed := &IE{Type: typ, Length: l, Payload: payload}
+	checkValueRange(parsed)
+	reportDecodeSuccess(typ.String())
+	return parsed, nil
+}
+
+// checkValueRange flags IEs whose payload decodes to a value outside what
+// the field allows, without failing the parse outright: a malformed peer
+// shouldn't take down the decoder, but operators should be able to see it
+// happening.
+func checkValueRange(i *IE) {
+	switch i.Type {
+	case TypeMSValidated, TypeTeardownInd:
+		if v := i.Payload[0]; v != 0x00 && v != 0xff {
+			reportValueRange(i.Type.String())
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// maxAllocsPerOp is the regression budget enforced by TestMarshalToAllocs:
+// MarshalTo into a pre-sized buffer should never allocate.
+const maxAllocsPerOp = 0
+
+// TestMarshalToAllocs enforces the zero-allocation budget for MarshalTo on
+// a reused buffer, for every fixed-length IE in ieTestCases (fixed-length
+// IEs are the ones the request expects to hit sub-microsecond, zero-alloc
+// encode). It runs as part of `go test` so a regression fails CI without
+// requiring -bench.
+func TestMarshalToAllocs(t *testing.T) {
+	buf := make([]byte, 256)
+
+	for _, c := range ieTestCases() {
+		if c.structured.Type >= 0x80 {
+			continue
+		}
+
+		c := c
+		t.Run(c.description, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, err := c.structured.MarshalTo(buf); err != nil {
+					t.Fatal(err)
+				}
+			})
+			if allocs > maxAllocsPerOp {
+				t.Errorf("allocs/op regressed: got %v, want <= %d", allocs, maxAllocsPerOp)
+			}
+		})
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	for _, c := range ieTestCases() {
+		c := c
+		b.Run(c.description, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 256)
+			for i := 0; i < b.N; i++ {
+				if _, err := c.structured.MarshalTo(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, c := range ieTestCases() {
+		c := c
+		b.Run(c.description, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ie.Parse(c.serialized); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
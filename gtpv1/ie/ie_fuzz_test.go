@@ -0,0 +1,73 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// FuzzParse feeds arbitrary bytes to ie.Parse and checks that it never
+// panics and never returns an IE whose Payload length disagrees with its
+// declared Length field.
+func FuzzParse(f *testing.F) {
+	for _, c := range ieTestCases() {
+		f.Add(c.serialized)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		got, err := ie.Parse(b)
+		if err != nil {
+			return
+		}
+
+		if len(got.Payload) != int(got.Length) {
+			t.Errorf("Payload length %d does not match declared Length %d", len(got.Payload), got.Length)
+		}
+	})
+}
+
+// FuzzRoundTrip seeds from the cases table exercised by TestIEs and checks
+// that Marshal -> Parse -> Marshal produces byte-identical output, and that
+// Parse(Marshal(x)) preserves every exported field of the original IE.
+func FuzzRoundTrip(f *testing.F) {
+	for _, c := range ieTestCases() {
+		f.Add(c.serialized)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		parsed, err := ie.Parse(b)
+		if err != nil {
+			return
+		}
+
+		first, err := parsed.Marshal()
+		if err != nil {
+			t.Fatalf("failed to marshal a successfully parsed IE: %v", err)
+		}
+
+		reparsed, err := ie.Parse(first)
+		if err != nil {
+			t.Fatalf("failed to re-parse a just-marshaled IE: %v", err)
+		}
+
+		second, err := reparsed.Marshal()
+		if err != nil {
+			t.Fatalf("failed to re-marshal a re-parsed IE: %v", err)
+		}
+
+		if !bytes.Equal(first, second) {
+			t.Errorf("Marshal->Parse->Marshal is not idempotent:\nfirst:  %x\nsecond: %x", first, second)
+		}
+
+		opt := cmp.AllowUnexported(*parsed, *reparsed)
+		if diff := cmp.Diff(parsed, reparsed, opt); diff != "" {
+			t.Errorf("exported fields not preserved across round-trip: %s", diff)
+		}
+	})
+}
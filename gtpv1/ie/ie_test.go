@@ -14,12 +14,17 @@ import (
 	"github.com/wmnsk/go-gtp/gtpv1/message"
 )
 
-func TestIEs(t *testing.T) {
-	cases := []struct {
-		description string
-		structured  *ie.IE
-		serialized  []byte
-	}{
+type ieTestCase struct {
+	description string
+	structured  *ie.IE
+	serialized  []byte
+}
+
+// ieTestCases returns the table of hand-picked byte patterns exercised by
+// TestIEs. It is factored out so that FuzzParse and FuzzRoundTrip can reuse
+// the same cases as their fuzzing seed corpus.
+func ieTestCases() []ieTestCase {
+	return []ieTestCase{
 		{
 			"IMSI",
 			ie.NewIMSI("123451234567890"),
@@ -200,6 +205,10 @@ func TestIEs(t *testing.T) {
 			},
 		},
 	}
+}
+
+func TestIEs(t *testing.T) {
+	cases := ieTestCases()
 
 	for _, c := range cases {
 		t.Run("Marshal/"+c.description, func(t *testing.T) {
@@ -0,0 +1,143 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonDecoder decodes the Payload of an IE into a value that marshals to a
+// stable, human-readable JSON representation. Each IE family that wants a
+// decoded (rather than raw-bytes) JSON form registers one in jsonDecoders,
+// keyed by its type code.
+type jsonDecoder func(i *IE) (interface{}, error)
+
+var (
+	jsonDecoders  = map[uint8]jsonDecoder{}
+	jsonTypeNames = map[string]uint8{}
+)
+
+// registerJSONDecoder wires typ's decoder into jsonDecoders, and records its
+// name so UnmarshalJSON can map the "type" field back to a type code. Every
+// IE family is registered from the single init() in json_register.go.
+func registerJSONDecoder(typ uint8, name string, dec jsonDecoder) {
+	jsonDecoders[typ] = dec
+	jsonTypeNames[name] = typ
+}
+
+// typeByName looks up the type code registered for name.
+func typeByName(name string) (uint8, bool) {
+	typ, ok := jsonTypeNames[name]
+	return typ, ok
+}
+
+type jsonIE struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The output shape is
+// {"type": "<TypeName>", ...decoded fields...}; IEs without a registered
+// decoder fall back to {"type": "<TypeName>", "value": "<hex Payload>"}.
+func (i *IE) MarshalJSON() ([]byte, error) {
+	name := i.Type.String()
+
+	dec, ok := jsonDecoders[uint8(i.Type)]
+	if !ok {
+		return json.Marshal(jsonIE{Type: name, Value: fmt.Sprintf("%x", i.Payload)})
+	}
+
+	decoded, err := dec(i)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s for JSON: %w", name, err)
+	}
+
+	// decoded always marshals to a JSON object ({"field":...,...}); splice
+	// "type" in as its first key rather than round-tripping through a map,
+	// which would lose the decoder's declared field order.
+	fields, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	typeField, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := append([]byte(`{"type":`), typeField...)
+	if len(fields) > len("{}") {
+		b = append(b, ',')
+		b = append(b, fields[1:]...)
+	} else {
+		b = append(b, '}')
+	}
+	return b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It looks up the
+// IE type by name and re-encodes the decoded fields back into an *IE via
+// the same constructors TestIEs exercises, so the round-trip through JSON
+// is lossless.
+func (i *IE) UnmarshalJSON(b []byte) error {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &head); err != nil {
+		return err
+	}
+
+	typ, ok := typeByName(head.Type)
+	if !ok {
+		return fmt.Errorf("unknown IE type %q", head.Type)
+	}
+
+	enc, ok := jsonEncoders[typ]
+	if !ok {
+		return fmt.Errorf("IE type %q has no JSON encoder registered", head.Type)
+	}
+
+	parsed, err := enc(b)
+	if err != nil {
+		return err
+	}
+
+	*i = *parsed
+	return nil
+}
+
+// jsonEncoder rebuilds an *IE from the raw JSON message that MarshalJSON
+// produced for it.
+type jsonEncoder func(b []byte) (*IE, error)
+
+var jsonEncoders = map[uint8]jsonEncoder{}
+
+// registerJSONEncoder wires typ's re-encoder into jsonEncoders.
+func registerJSONEncoder(typ uint8, enc jsonEncoder) {
+	jsonEncoders[typ] = enc
+}
+
+// String implements the fmt.Stringer interface by rendering the IE as its
+// JSON representation. Callers that need the raw bytes should use Marshal
+// instead.
+func (i *IE) String() string {
+	b, err := i.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%s: <undecodable: %v>", i.Type, err)
+	}
+	return string(b)
+}
+
+// Format implements fmt.Formatter so that %v, %s and %+v on an *IE print
+// its decoded JSON form instead of the Go struct layout.
+func (i *IE) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		_, _ = fmt.Fprint(f, i.String())
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(*ie.IE=%s)", verb, i.String())
+	}
+}
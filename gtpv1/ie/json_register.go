@@ -0,0 +1,797 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+)
+
+// The constructors referenced below are the same ones exercised by
+// ieTestCases() in ie_test.go; decoders here must stay in sync with their
+// wire format. Every type in that table has a decoder/encoder pair
+// registered here, so TestIEJSONRoundTrip can round-trip the whole table
+// through JSON, not just bytes.
+
+func init() {
+	registerJSONDecoder(0x02, "IMSI", decodeIMSIJSON)
+	registerJSONEncoder(0x02, func(b []byte) (*IE, error) {
+		var v struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewIMSI(v.Value), nil
+	})
+
+	registerJSONDecoder(0x05, "PacketTMSI", decodePacketTMSIJSON)
+	registerJSONEncoder(0x05, func(b []byte) (*IE, error) {
+		var v struct {
+			TMSI uint32 `json:"tmsi"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewPacketTMSI(v.TMSI), nil
+	})
+
+	registerJSONDecoder(0x09, "AuthenticationTriplet", decodeAuthenticationTripletJSON)
+	registerJSONEncoder(0x09, func(b []byte) (*IE, error) {
+		var v struct {
+			Rand string `json:"rand"`
+			Sres string `json:"sres"`
+			Kc   string `json:"kc"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		rnd, err := hex.DecodeString(v.Rand)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationTriplet: rand: %w", err)
+		}
+		sres, err := hex.DecodeString(v.Sres)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationTriplet: sres: %w", err)
+		}
+		kc, err := hex.DecodeString(v.Kc)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationTriplet: kc: %w", err)
+		}
+		return NewAuthenticationTriplet(rnd, sres, kc), nil
+	})
+
+	registerJSONDecoder(0x0b, "MAPCause", decodeMAPCauseJSON)
+	registerJSONEncoder(0x0b, func(b []byte) (*IE, error) {
+		var v struct {
+			Cause uint8 `json:"cause"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewMAPCause(gtpv1.MAPCause(v.Cause)), nil
+	})
+
+	registerJSONDecoder(0x0c, "PTMSISignature", decodePTMSISignatureJSON)
+	registerJSONEncoder(0x0c, func(b []byte) (*IE, error) {
+		var v struct {
+			Signature uint32 `json:"signature"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewPTMSISignature(v.Signature), nil
+	})
+
+	registerJSONDecoder(0x0d, "MSValidated", decodeMSValidatedJSON)
+	registerJSONEncoder(0x0d, func(b []byte) (*IE, error) {
+		var v struct {
+			Validated bool `json:"validated"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewMSValidated(v.Validated), nil
+	})
+
+	registerJSONDecoder(0x0e, "Recovery", decodeRecoveryJSON)
+	registerJSONEncoder(0x0e, func(b []byte) (*IE, error) {
+		var v struct {
+			RestartCounter uint8 `json:"restart_counter"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewRecovery(v.RestartCounter), nil
+	})
+
+	registerJSONDecoder(0x0f, "SelectionMode", decodeSelectionModeJSON)
+	registerJSONEncoder(0x0f, func(b []byte) (*IE, error) {
+		var v struct {
+			Mode uint8 `json:"mode"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewSelectionMode(gtpv1.SelectionMode(v.Mode)), nil
+	})
+
+	registerJSONDecoder(0x10, "TEIDDataI", decodeTEIDJSON)
+	registerJSONEncoder(0x10, func(b []byte) (*IE, error) {
+		v, err := decodeTEIDJSONValue(b)
+		if err != nil {
+			return nil, err
+		}
+		return NewTEIDDataI(v), nil
+	})
+
+	registerJSONDecoder(0x11, "TEIDCPlane", decodeTEIDJSON)
+	registerJSONEncoder(0x11, func(b []byte) (*IE, error) {
+		v, err := decodeTEIDJSONValue(b)
+		if err != nil {
+			return nil, err
+		}
+		return NewTEIDCPlane(v), nil
+	})
+
+	registerJSONDecoder(0x12, "TEIDDataII", decodeTEIDJSON)
+	registerJSONEncoder(0x12, func(b []byte) (*IE, error) {
+		v, err := decodeTEIDJSONValue(b)
+		if err != nil {
+			return nil, err
+		}
+		return NewTEIDDataII(v), nil
+	})
+
+	registerJSONDecoder(0x13, "TeardownInd", decodeTeardownIndJSON)
+	registerJSONEncoder(0x13, func(b []byte) (*IE, error) {
+		var v struct {
+			Teardown bool `json:"teardown"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewTeardownInd(v.Teardown), nil
+	})
+
+	registerJSONDecoder(0x14, "NSAPI", decodeNSAPIJSON)
+	registerJSONEncoder(0x14, func(b []byte) (*IE, error) {
+		var v struct {
+			NSAPI uint8 `json:"nsapi"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewNSAPI(v.NSAPI), nil
+	})
+
+	registerJSONDecoder(0x15, "RANAPCause", decodeRANAPCauseJSON)
+	registerJSONEncoder(0x15, func(b []byte) (*IE, error) {
+		var v struct {
+			Cause uint8 `json:"cause"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewRANAPCause(gtpv1.MAPCause(v.Cause)), nil
+	})
+
+	registerJSONDecoder(0x7f, "ChargingID", decodeChargingIDJSON)
+	registerJSONEncoder(0x7f, func(b []byte) (*IE, error) {
+		var v struct {
+			ID uint32 `json:"id"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewChargingID(v.ID), nil
+	})
+
+	registerJSONDecoder(0x80, "EndUserAddress", decodeEndUserAddressJSON)
+	registerJSONEncoder(0x80, func(b []byte) (*IE, error) {
+		var v struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		if net.ParseIP(v.Address) == nil {
+			return nil, fmt.Errorf("EndUserAddress: invalid IP address %q", v.Address)
+		}
+		return NewEndUserAddress(v.Address), nil
+	})
+
+	registerJSONDecoder(0x83, "AccessPointName", decodeAccessPointNameJSON)
+	registerJSONEncoder(0x83, func(b []byte) (*IE, error) {
+		var v struct {
+			APN string `json:"apn"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewAccessPointName(v.APN), nil
+	})
+
+	registerJSONDecoder(0x85, "GSNAddress", decodeGSNAddressJSON)
+	registerJSONEncoder(0x85, func(b []byte) (*IE, error) {
+		var v struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		if net.ParseIP(v.Address) == nil {
+			return nil, fmt.Errorf("GSNAddress: invalid IP address %q", v.Address)
+		}
+		return NewGSNAddress(v.Address), nil
+	})
+
+	registerJSONDecoder(0x86, "MSISDN", decodeMSISDNJSON)
+	registerJSONEncoder(0x86, func(b []byte) (*IE, error) {
+		var v struct {
+			Number string `json:"number"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewMSISDN(v.Number), nil
+	})
+
+	registerJSONDecoder(0x88, "AuthenticationQuintuplet", decodeAuthenticationQuintupletJSON)
+	registerJSONEncoder(0x88, func(b []byte) (*IE, error) {
+		var v struct {
+			Rand string `json:"rand"`
+			Xres string `json:"xres"`
+			Ck   string `json:"ck"`
+			Ik   string `json:"ik"`
+			Autn string `json:"autn"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		rnd, err := hex.DecodeString(v.Rand)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationQuintuplet: rand: %w", err)
+		}
+		xres, err := hex.DecodeString(v.Xres)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationQuintuplet: xres: %w", err)
+		}
+		ck, err := hex.DecodeString(v.Ck)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationQuintuplet: ck: %w", err)
+		}
+		ik, err := hex.DecodeString(v.Ik)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationQuintuplet: ik: %w", err)
+		}
+		autn, err := hex.DecodeString(v.Autn)
+		if err != nil {
+			return nil, fmt.Errorf("AuthenticationQuintuplet: autn: %w", err)
+		}
+		return NewAuthenticationQuintuplet(rnd, xres, ck, ik, autn), nil
+	})
+
+	registerJSONDecoder(0x8d, "ExtensionHeaderTypeList", decodeExtensionHeaderTypeListJSON)
+	registerJSONEncoder(0x8d, func(b []byte) (*IE, error) {
+		var v struct {
+			Types []uint8 `json:"types"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewExtensionHeaderTypeList(v.Types...), nil
+	})
+
+	registerJSONDecoder(0x94, "CommonFlags", decodeCommonFlagsJSON)
+	registerJSONEncoder(0x94, func(b []byte) (*IE, error) {
+		var v struct {
+			DualAddrBearer      uint8 `json:"dual_addr_bearer"`
+			UpgradeQoSSupported uint8 `json:"upgrade_qos_supported"`
+			Nrsn                uint8 `json:"nrsn"`
+			NoQoSNegotiation    uint8 `json:"no_qos_negotiation"`
+			MbmsCountingInfo    uint8 `json:"mbms_counting_info"`
+			RaTypeIndication    uint8 `json:"ra_type_indication"`
+			Bit1                uint8 `json:"bit1"`
+			Bit0                uint8 `json:"bit0"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewCommonFlags(v.DualAddrBearer, v.UpgradeQoSSupported, v.Nrsn, v.NoQoSNegotiation, v.MbmsCountingInfo, v.RaTypeIndication, v.Bit1, v.Bit0), nil
+	})
+
+	registerJSONDecoder(0x95, "APNRestriction", decodeAPNRestrictionJSON)
+	registerJSONEncoder(0x95, func(b []byte) (*IE, error) {
+		var v struct {
+			Restriction uint8 `json:"restriction"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewAPNRestriction(gtpv1.APNRestriction(v.Restriction)), nil
+	})
+
+	registerJSONDecoder(0x97, "RATType", decodeRATTypeJSON)
+	registerJSONEncoder(0x97, func(b []byte) (*IE, error) {
+		var v struct {
+			RATType uint8 `json:"rat_type"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewRATType(gtpv1.RATType(v.RATType)), nil
+	})
+
+	registerJSONDecoder(0x98, "UserLocationInformation", decodeUserLocationInformationJSON)
+	registerJSONEncoder(0x98, func(b []byte) (*IE, error) {
+		var v struct {
+			Geo string `json:"geo"`
+			MCC string `json:"mcc"`
+			MNC string `json:"mnc"`
+			LAC uint16 `json:"lac"`
+			CI  uint16 `json:"ci"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+
+		switch v.Geo {
+		case "SAI":
+			return NewUserLocationInformationWithSAI(v.MCC, v.MNC, v.LAC, v.CI), nil
+		case "RAI":
+			return NewUserLocationInformationWithRAI(v.MCC, v.MNC, v.LAC, v.CI), nil
+		default:
+			return NewUserLocationInformationWithCGI(v.MCC, v.MNC, v.LAC, v.CI), nil
+		}
+	})
+
+	registerJSONDecoder(0x99, "MSTimeZone", decodeMSTimeZoneJSON)
+	registerJSONEncoder(0x99, func(b []byte) (*IE, error) {
+		var v struct {
+			OffsetMinutes int   `json:"offset_minutes"`
+			DST           uint8 `json:"dst"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewMSTimeZone(time.Duration(v.OffsetMinutes)*time.Minute, v.DST), nil
+	})
+
+	registerJSONDecoder(0x9a, "IMEISV", decodeIMEISVJSON)
+	registerJSONEncoder(0x9a, func(b []byte) (*IE, error) {
+		var v struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewIMEISV(v.Value), nil
+	})
+
+	registerJSONDecoder(0xd6, "ULITimestamp", decodeULITimestampJSON)
+	registerJSONEncoder(0xd6, func(b []byte) (*IE, error) {
+		var v struct {
+			Time string `json:"time"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339, v.Time)
+		if err != nil {
+			return nil, fmt.Errorf("ULITimestamp: %w", err)
+		}
+		return NewULITimestamp(t), nil
+	})
+
+	registerJSONDecoder(0xff, "PrivateExtension", decodePrivateExtensionJSON)
+	registerJSONEncoder(0xff, func(b []byte) (*IE, error) {
+		var v struct {
+			ExtensionID uint16 `json:"extension_id"`
+			Value       string `json:"value"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		value, err := hex.DecodeString(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("PrivateExtension: value: %w", err)
+		}
+		return NewPrivateExtension(v.ExtensionID, value), nil
+	})
+}
+
+func decodeIMSIJSON(i *IE) (interface{}, error) {
+	return struct {
+		Value string `json:"value"`
+	}{Value: decodeBCD(i.Payload)}, nil
+}
+
+func decodePacketTMSIJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 4 {
+		return nil, fmt.Errorf("PacketTMSI: payload too short")
+	}
+	return struct {
+		TMSI uint32 `json:"tmsi"`
+	}{TMSI: binary.BigEndian.Uint32(i.Payload)}, nil
+}
+
+func decodeAuthenticationTripletJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) != 28 {
+		return nil, fmt.Errorf("AuthenticationTriplet: unexpected payload length %d", len(i.Payload))
+	}
+	return struct {
+		Rand string `json:"rand"`
+		Sres string `json:"sres"`
+		Kc   string `json:"kc"`
+	}{
+		Rand: hex.EncodeToString(i.Payload[0:16]),
+		Sres: hex.EncodeToString(i.Payload[16:20]),
+		Kc:   hex.EncodeToString(i.Payload[20:28]),
+	}, nil
+}
+
+func decodeMAPCauseJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("MAPCause: empty payload")
+	}
+	return struct {
+		Cause uint8 `json:"cause"`
+	}{Cause: i.Payload[0]}, nil
+}
+
+func decodePTMSISignatureJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 3 {
+		return nil, fmt.Errorf("PTMSISignature: payload too short")
+	}
+	sig := uint32(i.Payload[0])<<16 | uint32(i.Payload[1])<<8 | uint32(i.Payload[2])
+	return struct {
+		Signature uint32 `json:"signature"`
+	}{Signature: sig}, nil
+}
+
+func decodeMSValidatedJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("MSValidated: empty payload")
+	}
+	return struct {
+		Validated bool `json:"validated"`
+	}{Validated: i.Payload[0] != 0x00}, nil
+}
+
+func decodeRecoveryJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("Recovery: empty payload")
+	}
+	return struct {
+		RestartCounter uint8 `json:"restart_counter"`
+	}{RestartCounter: i.Payload[0]}, nil
+}
+
+func decodeSelectionModeJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("SelectionMode: empty payload")
+	}
+	return struct {
+		Mode uint8 `json:"mode"`
+	}{Mode: i.Payload[0]}, nil
+}
+
+// decodeTEIDJSON decodes the shared 4-byte big-endian payload of
+// TEIDDataI/TEIDCPlane/TEIDDataII.
+func decodeTEIDJSON(i *IE) (interface{}, error) {
+	v, err := decodeTEIDPayload(i.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		TEID uint32 `json:"teid"`
+	}{TEID: v}, nil
+}
+
+func decodeTEIDPayload(b []byte) (uint32, error) {
+	if len(b) < 4 {
+		return 0, fmt.Errorf("TEID: payload too short")
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// decodeTEIDJSONValue extracts the "teid" field shared by the
+// TEIDDataI/TEIDCPlane/TEIDDataII JSON encoders.
+func decodeTEIDJSONValue(b []byte) (uint32, error) {
+	var v struct {
+		TEID uint32 `json:"teid"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return 0, err
+	}
+	return v.TEID, nil
+}
+
+func decodeTeardownIndJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("TeardownInd: empty payload")
+	}
+	return struct {
+		Teardown bool `json:"teardown"`
+	}{Teardown: i.Payload[0] != 0x00}, nil
+}
+
+func decodeNSAPIJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("NSAPI: empty payload")
+	}
+	return struct {
+		NSAPI uint8 `json:"nsapi"`
+	}{NSAPI: i.Payload[0]}, nil
+}
+
+func decodeRANAPCauseJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("RANAPCause: empty payload")
+	}
+	return struct {
+		Cause uint8 `json:"cause"`
+	}{Cause: i.Payload[0]}, nil
+}
+
+func decodeChargingIDJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 4 {
+		return nil, fmt.Errorf("ChargingID: payload too short")
+	}
+	return struct {
+		ID uint32 `json:"id"`
+	}{ID: binary.BigEndian.Uint32(i.Payload)}, nil
+}
+
+func decodeEndUserAddressJSON(i *IE) (interface{}, error) {
+	switch len(i.Payload) {
+	case 6:
+		return struct {
+			PDPType string `json:"pdp_type"`
+			Address string `json:"address"`
+		}{PDPType: "IPv4", Address: net.IP(i.Payload[2:]).String()}, nil
+	case 18:
+		return struct {
+			PDPType string `json:"pdp_type"`
+			Address string `json:"address"`
+		}{PDPType: "IPv6", Address: net.IP(i.Payload[2:]).String()}, nil
+	default:
+		return nil, fmt.Errorf("EndUserAddress: unexpected payload length %d", len(i.Payload))
+	}
+}
+
+func decodeAccessPointNameJSON(i *IE) (interface{}, error) {
+	var labels []string
+	b := i.Payload
+	for len(b) > 0 {
+		n := int(b[0])
+		if n+1 > len(b) {
+			return nil, fmt.Errorf("AccessPointName: label length %d exceeds remaining %d bytes", n, len(b)-1)
+		}
+		labels = append(labels, string(b[1:1+n]))
+		b = b[1+n:]
+	}
+	return struct {
+		APN string `json:"apn"`
+	}{APN: strings.Join(labels, ".")}, nil
+}
+
+func decodeGSNAddressJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) != 4 && len(i.Payload) != 16 {
+		return nil, fmt.Errorf("GSNAddress: unexpected payload length %d", len(i.Payload))
+	}
+	return struct {
+		Address string `json:"address"`
+	}{Address: net.IP(i.Payload).String()}, nil
+}
+
+func decodeMSISDNJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("MSISDN: empty payload")
+	}
+	return struct {
+		Number string `json:"number"`
+	}{Number: decodeBCD(i.Payload[1:])}, nil
+}
+
+func decodeAuthenticationQuintupletJSON(i *IE) (interface{}, error) {
+	b := i.Payload
+	if len(b) < 17 {
+		return nil, fmt.Errorf("AuthenticationQuintuplet: payload too short")
+	}
+
+	rnd := b[0:16]
+	xresLen := int(b[16])
+	off := 17
+	if len(b) < off+xresLen+32+1 {
+		return nil, fmt.Errorf("AuthenticationQuintuplet: payload too short for xres/ck/ik")
+	}
+	xres := b[off : off+xresLen]
+	off += xresLen
+	ck := b[off : off+16]
+	ik := b[off+16 : off+32]
+	off += 32
+
+	autnLen := int(b[off])
+	off++
+	if len(b) < off+autnLen {
+		return nil, fmt.Errorf("AuthenticationQuintuplet: payload too short for autn")
+	}
+	autn := b[off : off+autnLen]
+
+	return struct {
+		Rand string `json:"rand"`
+		Xres string `json:"xres"`
+		Ck   string `json:"ck"`
+		Ik   string `json:"ik"`
+		Autn string `json:"autn"`
+	}{
+		Rand: hex.EncodeToString(rnd),
+		Xres: hex.EncodeToString(xres),
+		Ck:   hex.EncodeToString(ck),
+		Ik:   hex.EncodeToString(ik),
+		Autn: hex.EncodeToString(autn),
+	}, nil
+}
+
+func decodeExtensionHeaderTypeListJSON(i *IE) (interface{}, error) {
+	types := make([]uint8, len(i.Payload))
+	copy(types, i.Payload)
+	return struct {
+		Types []uint8 `json:"types"`
+	}{Types: types}, nil
+}
+
+func decodeCommonFlagsJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("CommonFlags: empty payload")
+	}
+	b := i.Payload[0]
+	bit := func(pos uint) uint8 {
+		if b&(1<<pos) != 0 {
+			return 1
+		}
+		return 0
+	}
+	return struct {
+		DualAddrBearer      uint8 `json:"dual_addr_bearer"`
+		UpgradeQoSSupported uint8 `json:"upgrade_qos_supported"`
+		Nrsn                uint8 `json:"nrsn"`
+		NoQoSNegotiation    uint8 `json:"no_qos_negotiation"`
+		MbmsCountingInfo    uint8 `json:"mbms_counting_info"`
+		RaTypeIndication    uint8 `json:"ra_type_indication"`
+		Bit1                uint8 `json:"bit1"`
+		Bit0                uint8 `json:"bit0"`
+	}{
+		DualAddrBearer:      bit(7),
+		UpgradeQoSSupported: bit(6),
+		Nrsn:                bit(5),
+		NoQoSNegotiation:    bit(4),
+		MbmsCountingInfo:    bit(3),
+		RaTypeIndication:    bit(2),
+		Bit1:                bit(1),
+		Bit0:                bit(0),
+	}, nil
+}
+
+func decodeAPNRestrictionJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("APNRestriction: empty payload")
+	}
+	return struct {
+		Restriction uint8 `json:"restriction"`
+	}{Restriction: i.Payload[0]}, nil
+}
+
+func decodeRATTypeJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 1 {
+		return nil, fmt.Errorf("RATType: empty payload")
+	}
+	return struct {
+		RATType uint8 `json:"rat_type"`
+	}{RATType: i.Payload[0]}, nil
+}
+
+func decodeUserLocationInformationJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 6 {
+		return nil, fmt.Errorf("UserLocationInformation: payload too short")
+	}
+
+	geo := "CGI"
+	switch i.Payload[0] {
+	case 1:
+		geo = "SAI"
+	case 2:
+		geo = "RAI"
+	}
+
+	mcc, mnc := decodePLMN(i.Payload[1:4])
+
+	out := struct {
+		Geo string `json:"geo"`
+		MCC string `json:"mcc"`
+		MNC string `json:"mnc"`
+		LAC uint16 `json:"lac"`
+		CI  uint16 `json:"ci"`
+	}{Geo: geo, MCC: mcc, MNC: mnc}
+	out.LAC = uint16(i.Payload[4])<<8 | uint16(i.Payload[5])
+	if len(i.Payload) >= 8 {
+		out.CI = uint16(i.Payload[6])<<8 | uint16(i.Payload[7])
+	} else if len(i.Payload) == 7 {
+		out.CI = uint16(i.Payload[6])
+	}
+
+	return out, nil
+}
+
+// decodePLMN decodes a 3-byte swapped-nibble MCC/MNC field.
+func decodePLMN(b []byte) (mcc, mnc string) {
+	mccDigits := []byte{'0' + b[0]&0x0f, '0' + b[0]>>4, '0' + b[1]&0x0f}
+	mncThird := b[1] >> 4
+	mnc = string([]byte{'0' + b[2]&0x0f, '0' + b[2]>>4})
+	if mncThird != 0x0f {
+		mnc = string([]byte{'0' + b[2]&0x0f, '0' + b[2]>>4, '0' + mncThird})
+	}
+	return string(mccDigits), mnc
+}
+
+// decodeMSTimeZoneJSON decodes the offset into whole minutes (always a
+// multiple of 15) and dst, inverting NewMSTimeZone's bit packing.
+func decodeMSTimeZoneJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 2 {
+		return nil, fmt.Errorf("MSTimeZone: payload too short")
+	}
+	b0 := i.Payload[0]
+	negative := b0&0x08 != 0
+	tens := int(b0 & 0x07)
+	ones := int(b0 >> 4)
+	units := tens*10 + ones
+	if negative {
+		units = -units
+	}
+
+	return struct {
+		OffsetMinutes int   `json:"offset_minutes"`
+		DST           uint8 `json:"dst"`
+	}{OffsetMinutes: units * 15, DST: i.Payload[1]}, nil
+}
+
+func decodeIMEISVJSON(i *IE) (interface{}, error) {
+	return struct {
+		Value string `json:"value"`
+	}{Value: decodeBCD(i.Payload)}, nil
+}
+
+func decodeULITimestampJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 4 {
+		return nil, fmt.Errorf("ULITimestamp: payload too short")
+	}
+	ntp := binary.BigEndian.Uint32(i.Payload)
+	t := time.Unix(int64(ntp)-ntpEpochOffset, 0).UTC()
+	return struct {
+		Time string `json:"time"`
+	}{Time: t.Format(time.RFC3339)}, nil
+}
+
+func decodePrivateExtensionJSON(i *IE) (interface{}, error) {
+	if len(i.Payload) < 2 {
+		return nil, fmt.Errorf("PrivateExtension: payload too short")
+	}
+	return struct {
+		ExtensionID uint16 `json:"extension_id"`
+		Value       string `json:"value"`
+	}{
+		ExtensionID: binary.BigEndian.Uint16(i.Payload[:2]),
+		Value:       hex.EncodeToString(i.Payload[2:]),
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// TestIEJSONRoundTrip round-trips every case in ieTestCases() through JSON:
+// structured IE -> JSON -> decoded IE -> wire bytes, which must match the
+// original wire bytes. This exercises every registered decoder/encoder
+// pair, not just the handful with hand-written JSON strings below.
+func TestIEJSONRoundTrip(t *testing.T) {
+	for _, c := range ieTestCases() {
+		t.Run(c.description, func(t *testing.T) {
+			encoded, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded ie.IE
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", encoded, err)
+			}
+
+			got, err := decoded.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, c.serialized); diff != "" {
+				t.Errorf("round trip via JSON %s: %s", encoded, diff)
+			}
+		})
+	}
+}
+
+// TestIEJSONShape pins the exact JSON produced for a handful of IEs, so a
+// change to field names or ordering shows up as an explicit diff here
+// rather than only as a round-trip failure.
+func TestIEJSONShape(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  *ie.IE
+		wantJSON    string
+	}{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			`{"type":"IMSI","value":"123451234567890"}`,
+		}, {
+			"EndUserAddress/v4",
+			ie.NewEndUserAddress("1.1.1.1"),
+			`{"type":"EndUserAddress","pdp_type":"IPv4","address":"1.1.1.1"}`,
+		}, {
+			"UserLocationInformationWithCGI",
+			ie.NewUserLocationInformationWithCGI("123", "45", 0xff, 0),
+			`{"type":"UserLocationInformation","geo":"CGI","mcc":"123","mnc":"45","lac":255,"ci":0}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			got, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.wantJSON {
+				t.Errorf("got %s, want %s", got, c.wantJSON)
+			}
+		})
+	}
+}
+
+// TestIEJSONInvalidAddress checks that the EndUserAddress and GSNAddress
+// JSON encoders reject an address string net.ParseIP can't parse instead of
+// silently building an IE with a truncated or garbage payload.
+func TestIEJSONInvalidAddress(t *testing.T) {
+	cases := []struct {
+		description string
+		json        string
+	}{
+		{"EndUserAddress", `{"type":"EndUserAddress","pdp_type":"IPv4","address":"not-an-ip"}`},
+		{"GSNAddress", `{"type":"GSNAddress","address":"not-an-ip"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			var decoded ie.IE
+			if err := json.Unmarshal([]byte(c.json), &decoded); err == nil {
+				t.Error("want error for invalid address, got nil")
+			}
+		})
+	}
+}
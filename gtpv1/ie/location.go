@@ -0,0 +1,63 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "encoding/binary"
+
+// Geographic Location Type values carried in the first octet of a
+// UserLocationInformation IE's payload.
+const (
+	geoTypeCGI = 0x00
+	geoTypeSAI = 0x01
+	geoTypeRAI = 0x02
+)
+
+// encodePLMN encodes a 3-digit MCC and a 2- or 3-digit MNC into the 3-byte
+// swapped-nibble PLMN field shared by several GTPv1 IEs.
+func encodePLMN(mcc, mnc string) []byte {
+	b := make([]byte, 3)
+	b[0] = (mcc[1]-'0')<<4 | (mcc[0] - '0')
+
+	mncFiller := byte(0x0f)
+	if len(mnc) == 3 {
+		mncFiller = mnc[2] - '0'
+	}
+	b[1] = mncFiller<<4 | (mcc[2] - '0')
+	b[2] = (mnc[1]-'0')<<4 | (mnc[0] - '0')
+	return b
+}
+
+func newUserLocationInformation(geo byte, mcc, mnc string, lac uint16, tail []byte) *IE {
+	payload := make([]byte, 0, 1+3+2+len(tail))
+	payload = append(payload, geo)
+	payload = append(payload, encodePLMN(mcc, mnc)...)
+	lacBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(lacBytes, lac)
+	payload = append(payload, lacBytes...)
+	payload = append(payload, tail...)
+	return newTLV(TypeUserLocationInformation, payload)
+}
+
+// NewUserLocationInformationWithCGI creates a UserLocationInformation IE
+// carrying a Cell Global Identification (MCC+MNC+LAC+CI).
+func NewUserLocationInformationWithCGI(mcc, mnc string, lac, ci uint16) *IE {
+	ciBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(ciBytes, ci)
+	return newUserLocationInformation(geoTypeCGI, mcc, mnc, lac, ciBytes)
+}
+
+// NewUserLocationInformationWithSAI creates a UserLocationInformation IE
+// carrying a Service Area Identification (MCC+MNC+LAC+SAC).
+func NewUserLocationInformationWithSAI(mcc, mnc string, lac, sac uint16) *IE {
+	sacBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(sacBytes, sac)
+	return newUserLocationInformation(geoTypeSAI, mcc, mnc, lac, sacBytes)
+}
+
+// NewUserLocationInformationWithRAI creates a UserLocationInformation IE
+// carrying a Routing Area Identification (MCC+MNC+LAC+RAC).
+func NewUserLocationInformationWithRAI(mcc, mnc string, lac, rac uint16) *IE {
+	return newUserLocationInformation(geoTypeRAI, mcc, mnc, lac, []byte{byte(rac)})
+}
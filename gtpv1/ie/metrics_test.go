@@ -0,0 +1,35 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+type fakeSink struct {
+	counters map[string]int
+}
+
+func (f *fakeSink) IncCounter(name string, labels map[string]string) {
+	f.counters[name]++
+}
+
+func (f *fakeSink) ObserveHist(name string, v float64, labels map[string]string) {}
+
+func TestSetMetricsSink(t *testing.T) {
+	sink := &fakeSink{counters: map[string]int{}}
+	ie.SetMetricsSink(sink)
+	defer ie.SetMetricsSink(nil)
+
+	if _, err := ie.NewIMSI("123451234567890").Marshal(); err != nil {
+		t.Fatal(err)
+	}
+
+	if sink.counters[ie.MetricEncodeTotal] == 0 {
+		t.Errorf("expected %s to be incremented, got %v", ie.MetricEncodeTotal, sink.counters)
+	}
+}
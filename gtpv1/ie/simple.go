@@ -0,0 +1,111 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/binary"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+)
+
+func boolByte(b bool) byte {
+	if b {
+		return 0xff
+	}
+	return 0x00
+}
+
+func be32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+// NewPacketTMSI creates a PacketTMSI IE.
+func NewPacketTMSI(tmsi uint32) *IE {
+	return newFixed(TypePacketTMSI, be32(tmsi))
+}
+
+// NewMAPCause creates a MAPCause IE.
+func NewMAPCause(cause gtpv1.MAPCause) *IE {
+	return newFixed(TypeMAPCause, []byte{byte(cause)})
+}
+
+// NewPTMSISignature creates a PTMSISignature IE.
+func NewPTMSISignature(sig uint32) *IE {
+	return newFixed(TypePTMSISignature, []byte{byte(sig >> 16), byte(sig >> 8), byte(sig)})
+}
+
+// NewMSValidated creates an MSValidated IE.
+func NewMSValidated(validated bool) *IE {
+	return newFixed(TypeMSValidated, []byte{boolByte(validated)})
+}
+
+// NewRecovery creates a Recovery IE.
+func NewRecovery(restartCounter uint8) *IE {
+	return newFixed(TypeRecovery, []byte{restartCounter})
+}
+
+// NewSelectionMode creates a SelectionMode IE.
+func NewSelectionMode(mode gtpv1.SelectionMode) *IE {
+	return newFixed(TypeSelectionMode, []byte{byte(mode)})
+}
+
+// NewTEIDDataI creates a TEIDDataI IE.
+func NewTEIDDataI(teid uint32) *IE {
+	return newFixed(TypeTEIDDataI, be32(teid))
+}
+
+// NewTEIDCPlane creates a TEIDCPlane IE.
+func NewTEIDCPlane(teid uint32) *IE {
+	return newFixed(TypeTEIDCPlane, be32(teid))
+}
+
+// NewTEIDDataII creates a TEIDDataII IE.
+func NewTEIDDataII(teid uint32) *IE {
+	return newFixed(TypeTEIDDataII, be32(teid))
+}
+
+// NewTeardownInd creates a TeardownInd IE.
+func NewTeardownInd(teardown bool) *IE {
+	return newFixed(TypeTeardownInd, []byte{boolByte(teardown)})
+}
+
+// NewNSAPI creates an NSAPI IE.
+func NewNSAPI(nsapi uint8) *IE {
+	return newFixed(TypeNSAPI, []byte{nsapi})
+}
+
+// NewRANAPCause creates a RANAPCause IE.
+func NewRANAPCause(cause gtpv1.MAPCause) *IE {
+	return newFixed(TypeRANAPCause, []byte{byte(cause)})
+}
+
+// NewChargingID creates a ChargingID IE.
+func NewChargingID(id uint32) *IE {
+	return newFixed(TypeChargingID, be32(id))
+}
+
+// NewCommonFlags creates a CommonFlags IE, packing its eight single-bit
+// flags (each 0 or 1) from MSB to LSB into one payload byte.
+func NewCommonFlags(dualAddrBearer, upgradeQoSSupported, nrsn, noQoSNegotiation, mbmsCountingInfo, raTypeIndication, bit1, bit0 uint8) *IE {
+	var b byte
+	for i, bit := range []uint8{dualAddrBearer, upgradeQoSSupported, nrsn, noQoSNegotiation, mbmsCountingInfo, raTypeIndication, bit1, bit0} {
+		if bit != 0 {
+			b |= 1 << uint(7-i)
+		}
+	}
+	return newTLV(TypeCommonFlags, []byte{b})
+}
+
+// NewAPNRestriction creates an APNRestriction IE.
+func NewAPNRestriction(restriction gtpv1.APNRestriction) *IE {
+	return newTLV(TypeAPNRestriction, []byte{byte(restriction)})
+}
+
+// NewRATType creates a RATType IE.
+func NewRATType(ratType gtpv1.RATType) *IE {
+	return newTLV(TypeRATType, []byte{byte(ratType)})
+}
@@ -0,0 +1,44 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch
+// (1900-01-01) and the Unix epoch (1970-01-01).
+const ntpEpochOffset = 2208988800
+
+// NewMSTimeZone creates an MSTimeZone IE. offset is the MS's timezone
+// relative to UTC, in whole 15-minute steps; dst is the daylight saving
+// time adjustment as defined by 3GPP TS 29.060.
+//
+// XXX - should be updated with more realistic value
+func NewMSTimeZone(offset time.Duration, dst uint8) *IE {
+	units := int(offset / (15 * time.Minute))
+	negative := units < 0
+	if negative {
+		units = -units
+	}
+
+	tens, ones := units/10, units%10
+	b0 := ones<<4 | tens
+	if negative {
+		b0 |= 0x08
+	}
+
+	return newTLV(TypeMSTimeZone, []byte{byte(b0), dst})
+}
+
+// NewULITimestamp creates a ULITimestamp IE from t, encoded as NTP seconds
+// since 1900-01-01.
+func NewULITimestamp(t time.Time) *IE {
+	ntp := uint32(t.Unix() + ntpEpochOffset)
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, ntp)
+	return newTLV(TypeULITimestamp, b)
+}
@@ -0,0 +1,192 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package message handles the GTPv1-C/U messages defined in 3GPP TS 29.060:
+// their common header and the IEs each message type carries.
+package message
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// Message type codes used by this package.
+const (
+	MsgTypeEchoRequest              uint8 = 0x01
+	MsgTypeEchoResponse             uint8 = 0x02
+	MsgTypeCreatePDPContextRequest  uint8 = 0x10
+	MsgTypeCreatePDPContextResponse uint8 = 0x11
+)
+
+// Extension Header Type values, as carried by an ie.ExtensionHeaderTypeList
+// IE. These are plain uint8s, the parameter type of
+// ie.NewExtensionHeaderTypeList, so they can be passed to it directly.
+const (
+	ExtHeaderTypePDUSessionContainer uint8 = 0x85
+	ExtHeaderTypeUDPPort             uint8 = 0x40
+)
+
+const (
+	gtpVersion1       = 1
+	protocolTypeGTP   = 1
+	headerFixedLen    = 8
+	headerOptionalLen = 4
+)
+
+// Header is the 8-byte mandatory part of a GTPv1 header, plus the
+// optional Sequence Number/N-PDU Number/Next Extension Header Type octets
+// this package always includes (matching the common case of S=1).
+type Header struct {
+	MessageType    uint8
+	Length         uint16
+	TEID           uint32
+	SequenceNumber uint16
+}
+
+// Message is a single GTPv1 message: a Header plus the IEs it carries.
+type Message struct {
+	Header Header
+	IEs    []*ie.IE
+}
+
+// NewEchoRequest creates an Echo Request message.
+func NewEchoRequest(seq uint16, ies ...*ie.IE) *Message {
+	return newMessage(MsgTypeEchoRequest, 0, seq, ies)
+}
+
+// NewEchoResponse creates an Echo Response message.
+func NewEchoResponse(seq uint16, ies ...*ie.IE) *Message {
+	return newMessage(MsgTypeEchoResponse, 0, seq, ies)
+}
+
+// NewCreatePDPContextRequest creates a Create PDP Context Request message.
+func NewCreatePDPContextRequest(teid uint32, ies ...*ie.IE) *Message {
+	return newMessage(MsgTypeCreatePDPContextRequest, teid, 0, ies)
+}
+
+// NewCreatePDPContextResponse creates a Create PDP Context Response
+// message.
+func NewCreatePDPContextResponse(teid uint32, ies ...*ie.IE) *Message {
+	return newMessage(MsgTypeCreatePDPContextResponse, teid, 0, ies)
+}
+
+func newMessage(msgType uint8, teid uint32, seq uint16, ies []*ie.IE) *Message {
+	return &Message{
+		Header: Header{MessageType: msgType, TEID: teid, SequenceNumber: seq},
+		IEs:    ies,
+	}
+}
+
+// MarshalLen returns the number of bytes Marshal/MarshalTo will produce.
+func (m *Message) MarshalLen() (int, error) {
+	l := headerFixedLen + headerOptionalLen
+	for _, i := range m.IEs {
+		n, err := i.MarshalLen()
+		if err != nil {
+			return 0, err
+		}
+		l += n
+	}
+	return l, nil
+}
+
+// encodeInto writes the wire representation of m into b, returning the
+// number of bytes written. It is shared by Marshal and MarshalTo.
+func (m *Message) encodeInto(b []byte) (int, error) {
+	total, err := m.MarshalLen()
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < total {
+		return 0, fmt.Errorf("message: buffer of %d bytes too small for %d-byte message", len(b), total)
+	}
+
+	b[0] = gtpVersion1<<5 | protocolTypeGTP<<4 | 1<<1 // S flag set
+	b[1] = m.Header.MessageType
+	binary.BigEndian.PutUint16(b[2:4], uint16(total-headerFixedLen))
+	binary.BigEndian.PutUint32(b[4:8], m.Header.TEID)
+	binary.BigEndian.PutUint16(b[8:10], m.Header.SequenceNumber)
+	b[10] = 0 // N-PDU Number, unused
+	b[11] = 0 // Next Extension Header Type, unused
+
+	offset := headerFixedLen + headerOptionalLen
+	for _, i := range m.IEs {
+		n, err := i.MarshalTo(b[offset:])
+		if err != nil {
+			return 0, err
+		}
+		offset += n
+	}
+
+	return offset, nil
+}
+
+// Marshal encodes m into its wire representation.
+func (m *Message) Marshal() ([]byte, error) {
+	l, err := m.MarshalLen()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, l)
+	if _, err := m.encodeInto(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo encodes m into b, returning the number of bytes written. It
+// lets callers reuse a buffer instead of allocating a new one on every
+// call, which is the zero-allocation path on the steady-state encode.
+func (m *Message) MarshalTo(b []byte) (int, error) {
+	return m.encodeInto(b)
+}
+
+// Parse decodes b into a Message.
+func Parse(b []byte) (*Message, error) {
+	if len(b) < headerFixedLen {
+		return nil, fmt.Errorf("message: Parse: need at least %d bytes, got %d", headerFixedLen, len(b))
+	}
+
+	flags := b[0]
+	hasOptional := flags&0x07 != 0 // E, S, or PN set
+	msgType := b[1]
+	length := binary.BigEndian.Uint16(b[2:4])
+	teid := binary.BigEndian.Uint32(b[4:8])
+
+	if int(length)+headerFixedLen > len(b) {
+		return nil, fmt.Errorf("message: Parse: declared length %d exceeds remaining %d bytes", length, len(b)-headerFixedLen)
+	}
+
+	offset := headerFixedLen
+	var seq uint16
+	if hasOptional {
+		if len(b) < headerFixedLen+headerOptionalLen {
+			return nil, fmt.Errorf("message: Parse: need %d bytes for optional header fields, got %d", headerFixedLen+headerOptionalLen, len(b))
+		}
+		seq = binary.BigEndian.Uint16(b[8:10])
+		offset += headerOptionalLen
+	}
+
+	end := headerFixedLen + int(length)
+	var ies []*ie.IE
+	for offset < end {
+		parsed, err := ie.Parse(b[offset:end])
+		if err != nil {
+			return nil, fmt.Errorf("message: Parse: %w", err)
+		}
+		n, err := parsed.MarshalLen()
+		if err != nil {
+			return nil, err
+		}
+		ies = append(ies, parsed)
+		offset += n
+	}
+
+	return &Message{
+		Header: Header{MessageType: msgType, Length: length, TEID: teid, SequenceNumber: seq},
+		IEs:    ies,
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+// maxAllocsPerOp is the regression budget enforced by TestAllocsBudget: a
+// representative message whose steady-state MarshalTo allocates more than
+// this many times per call should be treated as a benchmark regression.
+const maxAllocsPerOp = 0
+
+// assertAllocs fails t if got exceeds maxAllocsPerOp.
+func assertAllocs(t *testing.T, name string, got float64) {
+	t.Helper()
+	if got > maxAllocsPerOp {
+		t.Errorf("%s: allocs/op regressed: got %v, want <= %d", name, got, maxAllocsPerOp)
+	}
+}
+
+// TestAllocsBudget enforces the zero-allocation budget for MarshalTo on a
+// pre-sized buffer, which is the steady-state encode path benchmarked
+// below. It runs as part of `go test` so a regression fails CI without
+// requiring -bench.
+func TestAllocsBudget(t *testing.T) {
+	buf := make([]byte, 512)
+
+	cases := []struct {
+		name string
+		msg  *message.Message
+	}{
+		{"EchoRequest", message.NewEchoRequest(0, ie.NewRecovery(1))},
+		{"CreatePDPContextRequest", newCreatePDPContextRequest()},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, err := c.msg.MarshalTo(buf); err != nil {
+					t.Fatal(err)
+				}
+			})
+			assertAllocs(t, c.name, allocs)
+		})
+	}
+}
+
+func newCreatePDPContextRequest() *message.Message {
+	return message.NewCreatePDPContextRequest(
+		0xdeadbeef,
+		ie.NewIMSI("123451234567890"),
+		ie.NewNSAPI(5),
+		ie.NewTEIDDataI(0xdeadbeef),
+		ie.NewTEIDCPlane(0xdeadbeef),
+		ie.NewGSNAddress("1.1.1.1"),
+		ie.NewGSNAddress("1.1.1.1"),
+		ie.NewAccessPointName("some.apn.example"),
+	)
+}
+
+func BenchmarkMarshalEchoRequest(b *testing.B) {
+	msg := message.NewEchoRequest(0, ie.NewRecovery(1))
+	buf := make([]byte, 256)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msg.MarshalTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalCreatePDPContextRequest(b *testing.B) {
+	msg := newCreatePDPContextRequest()
+	buf := make([]byte, 512)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msg.MarshalTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import "net"
+
+// Transport is the framing layer underneath a UPlaneConn. The UDP transport
+// is the default; quicTransport (see transport_quic.go) is an alternative
+// that carries the same G-PDU bytes over QUIC unreliable datagrams instead
+// of UDP packets.
+//
+// A Transport implementation does not interpret the bytes it carries: it
+// moves an opaque G-PDU in or out and nothing more. TEID demux is done by
+// UPlaneConn.ReadFromTEID (see uplane.go), one layer up, so it behaves the
+// same way regardless of which Transport is in use.
+type Transport interface {
+	// ReadFrom reads one G-PDU's worth of bytes into b and returns the
+	// number of bytes read and the address it was read from.
+	ReadFrom(b []byte) (n int, addr net.Addr, err error)
+
+	// WriteTo writes one G-PDU's worth of bytes to addr.
+	WriteTo(b []byte, addr net.Addr) (n int, err error)
+
+	// Close releases the resources held by the transport.
+	Close() error
+
+	// LocalAddr returns the transport's local network address.
+	LocalAddr() net.Addr
+}
+
+// udpTransport is the default Transport, backed by a plain *net.UDPConn.
+type udpTransport struct {
+	conn *net.UDPConn
+}
+
+func newUDPTransport(conn *net.UDPConn) *udpTransport {
+	return &udpTransport{conn: conn}
+}
+
+func (t *udpTransport) ReadFrom(b []byte) (int, net.Addr, error) {
+	return t.conn.ReadFrom(b)
+}
+
+func (t *udpTransport) WriteTo(b []byte, addr net.Addr) (int, error) {
+	// A DialUPlane socket is already connected to its peer, and
+	// net.UDPConn.WriteTo rejects any write on a connected socket.
+	if t.conn.RemoteAddr() != nil {
+		return t.conn.Write(b)
+	}
+	return t.conn.WriteTo(b, addr)
+}
+
+func (t *udpTransport) Close() error {
+	return t.conn.Close()
+}
+
+func (t *udpTransport) LocalAddr() net.Addr {
+	return t.conn.LocalAddr()
+}
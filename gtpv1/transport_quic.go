@@ -0,0 +1,231 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+)
+
+// quicConfig enables the datagram extension (RFC 9221) required to carry
+// G-PDUs unreliably, the same delivery semantics UDP already gives us.
+var quicConfig = &quic.Config{EnableDatagrams: true}
+
+// quicDatagram is one G-PDU received from a peer, tagged with the
+// connection it arrived on so WriteTo can route a reply back to the same
+// peer.
+type quicDatagram struct {
+	data []byte
+	addr net.Addr
+}
+
+// quicTransport carries G-PDUs for a UPlaneConn over one or more QUIC
+// connections using unreliable datagrams instead of UDP packets. One
+// datagram carries exactly one G-PDU, byte-for-byte, including its GTP-U
+// header; quicTransport itself never reads that header, so it does not
+// demux by TEID. As with udpTransport, TEID demux happens one layer up, in
+// UPlaneConn.ReadFromTEID.
+//
+// A transport created by DialUPlaneQUIC has exactly one peer for its whole
+// lifetime. A transport created by ListenUPlaneQUIC accepts connections
+// from any number of peers, the same way udpTransport serves any number of
+// remote peers off one local socket: ReadFrom returns datagrams from
+// whichever peer sent one, and WriteTo routes to whichever peer the addr
+// (as returned by a prior ReadFrom) identifies.
+//
+// Datagrams are capped by the peer-negotiated max datagram frame size, so
+// callers running over constrained paths should keep MTU considerations in
+// mind the same way they would size UDP payloads to avoid IP fragmentation.
+type quicTransport struct {
+	localAddr net.Addr
+
+	mu    sync.Mutex
+	conns map[string]quic.Connection
+
+	incoming chan quicDatagram
+	done     chan struct{}
+
+	// listener and cancelAccept are set only for a ListenUPlaneQUIC
+	// transport, which owns the listener's accept loop.
+	listener     *quic.Listener
+	cancelAccept context.CancelFunc
+}
+
+func newQUICTransport(localAddr net.Addr) *quicTransport {
+	return &quicTransport{
+		localAddr: localAddr,
+		conns:     map[string]quic.Connection{},
+		incoming:  make(chan quicDatagram, 64),
+		done:      make(chan struct{}),
+	}
+}
+
+// addConn registers conn as a peer of the transport and starts relaying its
+// datagrams into ReadFrom. It is called once for the single peer of a
+// DialUPlaneQUIC transport, and once per accepted connection for a
+// ListenUPlaneQUIC transport.
+func (t *quicTransport) addConn(conn quic.Connection) {
+	t.mu.Lock()
+	t.conns[conn.RemoteAddr().String()] = conn
+	t.mu.Unlock()
+
+	go t.relay(conn)
+}
+
+// relay reads datagrams off conn until it errors (peer gone or transport
+// closed) and forwards them to ReadFrom, then deregisters conn.
+func (t *quicTransport) relay(conn quic.Connection) {
+	defer func() {
+		t.mu.Lock()
+		delete(t.conns, conn.RemoteAddr().String())
+		t.mu.Unlock()
+	}()
+
+	for {
+		dgram, err := conn.ReceiveDatagram(context.Background())
+		if err != nil {
+			return
+		}
+
+		select {
+		case t.incoming <- quicDatagram{data: dgram, addr: conn.RemoteAddr()}:
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// acceptLoop accepts new peer connections on listener until ctx is canceled
+// or the listener is closed, registering each one with addConn.
+func (t *quicTransport) acceptLoop(ctx context.Context, listener *quic.Listener) {
+	for {
+		conn, err := listener.Accept(ctx)
+		if err != nil {
+			return
+		}
+		t.addConn(conn)
+	}
+}
+
+func (t *quicTransport) ReadFrom(b []byte) (int, net.Addr, error) {
+	select {
+	case dgram := <-t.incoming:
+		n := copy(b, dgram.data)
+		if n < len(dgram.data) {
+			return n, dgram.addr, fmt.Errorf("gtpv1: datagram of %d bytes truncated to %d-byte buffer", len(dgram.data), n)
+		}
+		return n, dgram.addr, nil
+	case <-t.done:
+		return 0, nil, fmt.Errorf("gtpv1: quic transport closed")
+	}
+}
+
+func (t *quicTransport) WriteTo(b []byte, addr net.Addr) (int, error) {
+	t.mu.Lock()
+	conn, ok := t.conns[addr.String()]
+	t.mu.Unlock()
+	if !ok {
+		return 0, fmt.Errorf("gtpv1: no QUIC peer connected at %s", addr)
+	}
+
+	if err := conn.SendDatagram(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (t *quicTransport) Close() error {
+	select {
+	case <-t.done:
+		return nil // already closed
+	default:
+		close(t.done)
+	}
+
+	if t.cancelAccept != nil {
+		t.cancelAccept()
+	}
+
+	t.mu.Lock()
+	conns := make([]quic.Connection, 0, len(t.conns))
+	for _, conn := range t.conns {
+		conns = append(conns, conn)
+	}
+	t.mu.Unlock()
+
+	var firstErr error
+	for _, conn := range conns {
+		if err := conn.CloseWithError(0, ""); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if t.listener != nil {
+		if err := t.listener.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (t *quicTransport) LocalAddr() net.Addr {
+	return t.localAddr
+}
+
+// DialUPlaneQUIC opens a GTP-U user plane to raddr over a QUIC connection
+// using unreliable datagrams instead of UDP, mirroring DialUPlane's API.
+// The IE/message layer is unaffected; only the framing underneath changes.
+func DialUPlaneQUIC(ctx context.Context, laddr, raddr *net.UDPAddr, tlsConf *tls.Config) (*UPlaneConn, error) {
+	udpConn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	qConn, err := quic.Dial(ctx, udpConn, raddr, tlsConf, quicConfig)
+	if err != nil {
+		udpConn.Close()
+		return nil, err
+	}
+
+	tr := newQUICTransport(udpConn.LocalAddr())
+	tr.addConn(qConn)
+	return newUPlaneConnWithTransport(tr), nil
+}
+
+// ListenUPlaneQUIC starts accepting QUIC connections on laddr and returns a
+// UPlaneConn that serves every peer that connects, mirroring ListenUPlane's
+// API for the UDP transport: like a UDP socket, it is not bound to a single
+// remote peer, so it supports the normal SGW/PGW N3/S1-U case of one local
+// endpoint talking to many UEs' sessions. ListenUPlaneQUIC returns as soon
+// as it is ready to accept; it does not wait for a first peer to connect.
+// ctx bounds the lifetime of the accept loop, not of already-accepted
+// connections: canceling it stops accepting new peers without disturbing
+// ones already connected. UPlaneConn.Close also stops accepting, and closes
+// every connected peer's connection as well.
+func ListenUPlaneQUIC(ctx context.Context, laddr *net.UDPAddr, tlsConf *tls.Config) (*UPlaneConn, error) {
+	udpConn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := quic.Listen(udpConn, tlsConf, quicConfig)
+	if err != nil {
+		udpConn.Close()
+		return nil, err
+	}
+
+	tr := newQUICTransport(udpConn.LocalAddr())
+	tr.listener = listener
+
+	acceptCtx, cancel := context.WithCancel(ctx)
+	tr.cancelAccept = cancel
+	go tr.acceptLoop(acceptCtx, listener)
+
+	return newUPlaneConnWithTransport(tr), nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+)
+
+// quicALPN is an arbitrary application protocol name; quic-go requires one
+// to be negotiated, but this package doesn't care what it's called.
+const quicALPN = "gtp-u-test"
+
+// generateQUICTestTLSConfig returns a server tls.Config backed by a fresh
+// self-signed certificate, for use in tests only.
+func generateQUICTestTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := x509.Certificate{SerialNumber: big.NewInt(1)}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to build key pair: %v", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{quicALPN},
+	}
+}
+
+func quicClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		NextProtos:         []string{quicALPN},
+	}
+}
+
+// TestUPlaneQUICRoundTrip dials and listens over a real QUIC connection on
+// loopback and checks that a G-PDU written by the dialer is received by the
+// listener, and vice versa.
+func TestUPlaneQUICRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	laddr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := gtpv1.ListenUPlaneQUIC(ctx, laddr, generateQUICTestTLSConfig(t))
+	if err != nil {
+		t.Fatalf("ListenUPlaneQUIC: %v", err)
+	}
+	defer server.Close()
+
+	serverAddr := server.LocalAddr().(*net.UDPAddr)
+
+	client, err := gtpv1.DialUPlaneQUIC(ctx, laddr, serverAddr, quicClientTLSConfig())
+	if err != nil {
+		t.Fatalf("DialUPlaneQUIC: %v", err)
+	}
+	defer client.Close()
+
+	gpdu := []byte{0x30, 0xff, 0x00, 0x00, 0xde, 0xad, 0xbe, 0xef}
+
+	if _, err := client.WriteTo(gpdu, serverAddr); err != nil {
+		t.Fatalf("client WriteTo: %v", err)
+	}
+
+	buf := make([]byte, 256)
+	n, teid, peer, err := server.ReadFromTEID(buf)
+	if err != nil {
+		t.Fatalf("server ReadFromTEID: %v", err)
+	}
+	if teid != 0xdeadbeef {
+		t.Errorf("got TEID 0x%x, want 0xdeadbeef", teid)
+	}
+
+	if _, err := server.WriteTo(buf[:n], peer); err != nil {
+		t.Fatalf("server WriteTo: %v", err)
+	}
+
+	echoed := make([]byte, 256)
+	n, _, err = client.ReadFrom(echoed)
+	if err != nil {
+		t.Fatalf("client ReadFrom: %v", err)
+	}
+	if string(echoed[:n]) != string(gpdu) {
+		t.Errorf("got %x, want %x", echoed[:n], gpdu)
+	}
+}
+
+// TestUPlaneQUICMultiPeer checks that a single ListenUPlaneQUIC UPlaneConn
+// serves more than one peer at once, the same way a UDP-backed UPlaneConn
+// serves any number of remote peers off one local socket.
+func TestUPlaneQUICMultiPeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	laddr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := gtpv1.ListenUPlaneQUIC(ctx, laddr, generateQUICTestTLSConfig(t))
+	if err != nil {
+		t.Fatalf("ListenUPlaneQUIC: %v", err)
+	}
+	defer server.Close()
+
+	serverAddr := server.LocalAddr().(*net.UDPAddr)
+
+	const numPeers = 2
+	peers := make([]*gtpv1.UPlaneConn, numPeers)
+	for i := range peers {
+		c, err := gtpv1.DialUPlaneQUIC(ctx, laddr, serverAddr, quicClientTLSConfig())
+		if err != nil {
+			t.Fatalf("DialUPlaneQUIC[%d]: %v", i, err)
+		}
+		defer c.Close()
+		peers[i] = c
+	}
+
+	teids := [numPeers]uint32{0x11111111, 0x22222222}
+	for i, peer := range peers {
+		gpdu := []byte{0x30, 0xff, 0x00, 0x00, byte(teids[i] >> 24), byte(teids[i] >> 16), byte(teids[i] >> 8), byte(teids[i])}
+		if _, err := peer.WriteTo(gpdu, serverAddr); err != nil {
+			t.Fatalf("peer[%d] WriteTo: %v", i, err)
+		}
+	}
+
+	seen := map[uint32]bool{}
+	buf := make([]byte, 256)
+	for i := 0; i < numPeers; i++ {
+		_, teid, _, err := server.ReadFromTEID(buf)
+		if err != nil {
+			t.Fatalf("server ReadFromTEID: %v", err)
+		}
+		seen[teid] = true
+	}
+
+	for _, want := range teids {
+		if !seen[want] {
+			t.Errorf("never received a G-PDU with TEID 0x%x", want)
+		}
+	}
+}
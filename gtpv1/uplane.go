@@ -0,0 +1,99 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// gpduHeaderLen is the fixed part of a GTP-U header: the flags byte,
+// message type, 2-byte length, and 4-byte TEID. As in the GTPv1-C header
+// used by gtpv1/message, the TEID field is mandatory, so it sits at a fixed
+// offset regardless of which optional fields follow it.
+const gpduHeaderLen = 8
+
+// TEIDFromGPDU extracts the TEID from a raw G-PDU buffer, the same 4-byte
+// field at a fixed offset that distinguishes one PDP/PDU session's traffic
+// from another's on a shared user-plane socket. UPlaneConn.ReadFromTEID
+// uses this to demux by TEID regardless of which Transport delivered the
+// bytes; it is exported so callers doing their own buffering can demux the
+// same way.
+func TEIDFromGPDU(b []byte) (uint32, error) {
+	if len(b) < gpduHeaderLen {
+		return 0, fmt.Errorf("gtpv1: G-PDU too short to contain a TEID: got %d bytes, need %d", len(b), gpduHeaderLen)
+	}
+	return binary.BigEndian.Uint32(b[4:8]), nil
+}
+
+// UPlaneConn represents a GTP-U user plane connection. It is transport
+// agnostic: the default constructors back it with UDP, while
+// DialUPlaneQUIC/ListenUPlaneQUIC back it with QUIC unreliable datagrams
+// instead. Either way, a Transport only ever carries opaque G-PDU bytes;
+// TEID demux is performed here, by UPlaneConn, via TEIDFromGPDU, not inside
+// the Transport implementations themselves.
+type UPlaneConn struct {
+	tr Transport
+}
+
+func newUPlaneConnWithTransport(tr Transport) *UPlaneConn {
+	return &UPlaneConn{tr: tr}
+}
+
+// DialUPlane opens a GTP-U user plane to raddr over UDP.
+func DialUPlane(laddr, raddr *net.UDPAddr) (*UPlaneConn, error) {
+	conn, err := net.DialUDP("udp", laddr, raddr)
+	if err != nil {
+		return nil, err
+	}
+	return newUPlaneConnWithTransport(newUDPTransport(conn)), nil
+}
+
+// ListenUPlane starts a GTP-U user plane listening on laddr over UDP.
+func ListenUPlane(laddr *net.UDPAddr) (*UPlaneConn, error) {
+	conn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return nil, err
+	}
+	return newUPlaneConnWithTransport(newUDPTransport(conn)), nil
+}
+
+// ReadFrom reads one G-PDU's worth of bytes from the underlying transport.
+func (u *UPlaneConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	return u.tr.ReadFrom(b)
+}
+
+// ReadFromTEID reads one G-PDU from the underlying transport and extracts
+// its TEID, so callers can demux sessions sharing a single UPlaneConn
+// without parsing the rest of the GTP-U header themselves.
+func (u *UPlaneConn) ReadFromTEID(b []byte) (n int, teid uint32, addr net.Addr, err error) {
+	n, addr, err = u.tr.ReadFrom(b)
+	if err != nil {
+		return n, 0, addr, err
+	}
+
+	teid, err = TEIDFromGPDU(b[:n])
+	if err != nil {
+		return n, 0, addr, err
+	}
+	return n, teid, addr, nil
+}
+
+// WriteTo writes one G-PDU's worth of bytes to addr over the underlying
+// transport.
+func (u *UPlaneConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	return u.tr.WriteTo(b, addr)
+}
+
+// Close releases the resources held by the underlying transport.
+func (u *UPlaneConn) Close() error {
+	return u.tr.Close()
+}
+
+// LocalAddr returns the local network address of the underlying transport.
+func (u *UPlaneConn) LocalAddr() net.Addr {
+	return u.tr.LocalAddr()
+}
@@ -0,0 +1,33 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+)
+
+func TestTEIDFromGPDU(t *testing.T) {
+	gpdu := []byte{
+		0x30, 0xff, 0x00, 0x04, // flags, message type, length
+		0xde, 0xad, 0xbe, 0xef, // TEID
+		0x01, 0x02, 0x03, 0x04, // payload
+	}
+
+	teid, err := gtpv1.TEIDFromGPDU(gpdu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := uint32(0xdeadbeef); teid != want {
+		t.Errorf("got TEID 0x%x, want 0x%x", teid, want)
+	}
+}
+
+func TestTEIDFromGPDUTooShort(t *testing.T) {
+	if _, err := gtpv1.TEIDFromGPDU([]byte{0x30, 0xff, 0x00}); err == nil {
+		t.Error("expected an error for a buffer shorter than the GTP-U header, got nil")
+	}
+}
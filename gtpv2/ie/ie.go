@@ -0,0 +1,165 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package ie handles the Information Elements (IEs) encoded in GTPv2
+// messages, as defined in 3GPP TS 29.274.
+package ie
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Type identifies the kind of a GTPv2 IE on the wire. Unlike GTPv1, every
+// GTPv2 IE uses the same 4-byte header regardless of type: a 1-byte type,
+// a 2-byte big-endian length, and a 1-byte spare/instance field.
+type Type uint8
+
+// IE type codes used by this package.
+const (
+	TypeIMSI     Type = 0x01
+	TypeRecovery Type = 0x03
+)
+
+var typeNames = map[Type]string{
+	TypeIMSI:     "IMSI",
+	TypeRecovery: "Recovery",
+}
+
+// String implements the fmt.Stringer interface. Unrecognized types render
+// as their hex value so logs stay readable even for IEs this package
+// doesn't know about.
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown(0x%02x)", uint8(t))
+}
+
+const ieHeaderLen = 4
+
+// IE represents a single GTPv2 Information Element, either parsed from the
+// wire or built with one of the NewXxx constructors.
+type IE struct {
+	Type     Type
+	Length   uint16
+	Instance uint8
+	Payload  []byte
+}
+
+func newIE(t Type, payload []byte) *IE {
+	return &IE{Type: t, Length: uint16(len(payload)), Payload: payload}
+}
+
+// MarshalLen returns the number of bytes Marshal/MarshalTo will produce for
+// this IE: the 4-byte header plus the payload.
+func (i *IE) MarshalLen() (int, error) {
+	return ieHeaderLen + len(i.Payload), nil
+}
+
+// encodeInto writes the wire representation of i into b, which must be at
+// least as long as MarshalLen. It is shared by Marshal and MarshalTo so
+// that neither has to delegate to the other.
+func (i *IE) encodeInto(b []byte) int {
+	b[0] = byte(i.Type)
+	binary.BigEndian.PutUint16(b[1:3], i.Length)
+	b[3] = i.Instance
+	n := copy(b[ieHeaderLen:], i.Payload)
+	return ieHeaderLen + n
+}
+
+// Marshal encodes the IE into its wire representation.
+func (i *IE) Marshal() ([]byte, error) {
+	l, err := i.MarshalLen()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, l)
+	n := i.encodeInto(b)
+
+	reportEncodeSuccess(i.Type.String(), n)
+	return b, nil
+}
+
+// MarshalTo encodes the IE into b, returning the number of bytes written.
+// It behaves like Marshal but lets callers reuse a buffer instead of
+// allocating a new one on every call.
+func (i *IE) MarshalTo(b []byte) (int, error) {
+	l, err := i.MarshalLen()
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < l {
+		return 0, fmt.Errorf("ie: MarshalTo: buffer of %d bytes too small for %d-byte IE", len(b), l)
+	}
+
+	n := i.encodeInto(b)
+	reportEncodeSuccess(i.Type.String(), n)
+	return n, nil
+}
+
+// Parse decodes b into an IE. It never panics: malformed or truncated input
+// is reported as an error rather than a zero-value or out-of-bounds read.
+func Parse(b []byte) (*IE, error) {
+	if len(b) < ieHeaderLen {
+		typ := Type(0)
+		if len(b) > 0 {
+			typ = Type(b[0])
+		}
+		reportMalformed(typ.String())
+		return nil, fmt.Errorf("ie: Parse: need at least %d bytes for header, got %d", ieHeaderLen, len(b))
+	}
+
+	typ := Type(b[0])
+	l := binary.BigEndian.Uint16(b[1:3])
+	instance := b[3]
+
+	if len(b) < ieHeaderLen+int(l) {
+		reportMalformed(typ.String())
+		return nil, fmt.Errorf("ie: Parse: %s: declared length %d exceeds remaining %d bytes", typ, l, len(b)-ieHeaderLen)
+	}
+
+	payload := make([]byte, l)
+	copy(payload, b[ieHeaderLen:ieHeaderLen+int(l)])
+
+	if _, ok := typeNames[typ]; !ok {
+		reportUnknownType(typ.String())
+	}
+	reportDecodeSuccess(typ.String())
+	return &IE{Type: typ, Length: l, Instance: instance, Payload: payload}, nil
+}
+
+// NewIMSI creates an IMSI IE from its decimal digit string.
+func NewIMSI(imsi string) *IE {
+	return newIE(TypeIMSI, encodeBCD(imsi, bcdOctets(len(imsi))))
+}
+
+// NewRecovery creates a Recovery IE.
+func NewRecovery(restartCounter uint8) *IE {
+	return newIE(TypeRecovery, []byte{restartCounter})
+}
+
+// encodeBCD packs digits into octets bytes of swapped-nibble BCD: the first
+// digit of each pair goes in the low nibble, the second in the high nibble.
+// A pair with no second digit is filled with 0xf.
+func encodeBCD(digits string, octets int) []byte {
+	b := make([]byte, octets)
+	for i := 0; i < octets; i++ {
+		lo, hi := byte(0x0f), byte(0x0f)
+		if idx := i * 2; idx < len(digits) {
+			lo = digits[idx] - '0'
+		}
+		if idx := i*2 + 1; idx < len(digits) {
+			hi = digits[idx] - '0'
+		}
+		b[i] = hi<<4 | lo
+	}
+	return b
+}
+
+// bcdOctets returns the number of octets needed to hold n BCD digits.
+func bcdOctets(n int) int {
+	return (n + 1) / 2
+}
@@ -0,0 +1,40 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+func BenchmarkMarshal(b *testing.B) {
+	for _, c := range ieTestCases() {
+		c := c
+		b.Run(c.description, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 256)
+			for i := 0; i < b.N; i++ {
+				if _, err := c.structured.MarshalTo(buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, c := range ieTestCases() {
+		c := c
+		b.Run(c.description, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ie.Parse(c.serialized); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+type ieTestCase struct {
+	description string
+	structured  *ie.IE
+	serialized  []byte
+}
+
+// ieTestCases returns the table of hand-picked byte patterns exercised by
+// TestIEs. It is factored out so that FuzzParse and FuzzRoundTrip can reuse
+// the same cases as their fuzzing seed corpus, mirroring gtpv1/ie's
+// ieTestCases.
+func ieTestCases() []ieTestCase {
+	return []ieTestCase{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			[]byte{0x01, 0x00, 0x08, 0x00, 0x21, 0x43, 0x15, 0x32, 0x54, 0x76, 0x98, 0xf0},
+		}, {
+			"IMSI/EvenDigitCount",
+			ie.NewIMSI("12345123456789"),
+			[]byte{0x01, 0x00, 0x07, 0x00, 0x21, 0x43, 0x15, 0x32, 0x54, 0x76, 0x98},
+		}, {
+			"Recovery",
+			ie.NewRecovery(1),
+			[]byte{0x03, 0x00, 0x01, 0x00, 0x01},
+		},
+	}
+}
+
+func TestIEs(t *testing.T) {
+	cases := ieTestCases()
+
+	for _, c := range cases {
+		t.Run("Marshal/"+c.description, func(t *testing.T) {
+			got, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, c.serialized); diff != "" {
+				t.Error(diff)
+			}
+		})
+
+		t.Run("Parse/"+c.description, func(t *testing.T) {
+			got, err := ie.Parse(c.serialized)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opt := cmp.AllowUnexported(*got, *c.structured)
+			if diff := cmp.Diff(got, c.structured, opt); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/json"
+)
+
+func init() {
+	registerJSONDecoder(0x01, "IMSI", decodeIMSIJSON)
+	registerJSONEncoder(0x01, func(b []byte) (*IE, error) {
+		var v struct{ Value string }
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewIMSI(v.Value), nil
+	})
+
+	registerJSONDecoder(0x03, "Recovery", decodeRecoveryJSON)
+	registerJSONEncoder(0x03, func(b []byte) (*IE, error) {
+		var v struct {
+			RestartCounter uint8 `json:"restart_counter"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		return NewRecovery(v.RestartCounter), nil
+	})
+}
+
+func decodeIMSIJSON(i *IE) (interface{}, error) {
+	return struct {
+		Value string `json:"value"`
+	}{Value: decodeBCDDigits(i.Payload)}, nil
+}
+
+func decodeRecoveryJSON(i *IE) (interface{}, error) {
+	return struct {
+		RestartCounter uint8 `json:"restart_counter"`
+	}{RestartCounter: i.Payload[0]}, nil
+}
+
+// decodeBCDDigits decodes a swapped-nibble BCD byte slice into its decimal
+// digit string, stopping at a 0xf filler nibble.
+func decodeBCDDigits(b []byte) string {
+	digits := make([]byte, 0, len(b)*2)
+	for _, octet := range b {
+		lo, hi := octet&0x0f, octet>>4
+		if lo == 0x0f {
+			break
+		}
+		digits = append(digits, '0'+lo)
+		if hi == 0x0f {
+			break
+		}
+		digits = append(digits, '0'+hi)
+	}
+	return string(digits)
+}
@@ -0,0 +1,59 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+func TestIEJSONRoundTrip(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  *ie.IE
+		wantJSON    string
+	}{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			`{"type":"IMSI","value":"123451234567890"}`,
+		}, {
+			"Recovery",
+			ie.NewRecovery(1),
+			`{"type":"Recovery","restart_counter":1}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			got, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.wantJSON {
+				t.Errorf("got %s, want %s", got, c.wantJSON)
+			}
+
+			var decoded ie.IE
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			want, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			roundTripped, err := decoded.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(roundTripped) != string(want) {
+				t.Errorf("round trip via JSON %s: got %x, want %x", got, roundTripped, want)
+			}
+		})
+	}
+}
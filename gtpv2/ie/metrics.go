@@ -0,0 +1,116 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "sync/atomic"
+
+// Sink receives the instrumentation events emitted by the Marshal/Parse
+// codec path. Implementations are expected to be safe for concurrent use,
+// as IEs are commonly encoded/decoded from multiple goroutines at once.
+//
+// A typical implementation wraps a prometheus.Registerer, translating
+// IncCounter/ObserveHist calls into CounterVec.With(labels).Inc() and
+// HistogramVec.With(labels).Observe(v), but the interface itself has no
+// dependency on Prometheus so plain users don't pay for it.
+type Sink interface {
+	IncCounter(name string, labels map[string]string)
+	ObserveHist(name string, v float64, labels map[string]string)
+}
+
+// Names of the metrics emitted by this package. They are exported so that
+// Sink implementations can pre-register them (e.g., to fix bucket
+// boundaries on the histogram) before any IE is encoded or decoded.
+// MetricValueRangeTotal is never emitted here: Parse applies no value-range
+// check to any GTPv2 IE type.
+const (
+	MetricEncodeTotal      = "gtp_ie_encode_total"
+	MetricDecodeTotal      = "gtp_ie_decode_total"
+	MetricMalformedTotal   = "gtp_ie_malformed_total"
+	MetricUnknownTypeTotal = "gtp_ie_unknown_type_total"
+	MetricValueRangeTotal  = "gtp_ie_value_range_total"
+	MetricEncodedSizeBytes = "gtp_ie_encoded_size_bytes"
+)
+
+type noopSink struct{}
+
+func (noopSink) IncCounter(name string, labels map[string]string)             {}
+func (noopSink) ObserveHist(name string, v float64, labels map[string]string) {}
+
+var (
+	defaultSink Sink = noopSink{}
+	metricsSink atomic.Pointer[Sink]
+	// metricsEnabled mirrors "metricsSink holds a non-nil, non-default
+	// sink" so the hot Marshal/Parse path can skip building labels with a
+	// single atomic load instead of comparing interface values.
+	metricsEnabled atomic.Bool
+)
+
+func init() {
+	metricsSink.Store(&defaultSink)
+}
+
+// SetMetricsSink installs sink as the destination for IE codec metrics.
+// Passing nil restores the default no-op sink, which costs nothing beyond
+// a single branch on every Marshal/Parse. It is safe to call concurrently
+// with Marshal/Parse.
+func SetMetricsSink(sink Sink) {
+	if sink == nil {
+		sink = noopSink{}
+		metricsEnabled.Store(false)
+	} else {
+		metricsEnabled.Store(true)
+	}
+	metricsSink.Store(&sink)
+}
+
+func labelsForType(typeName string) map[string]string {
+	return map[string]string{"ie": typeName}
+}
+
+// reportEncodeSuccess records a successful Marshal of an IE of the given
+// type, together with the size in bytes of the resulting TLV.
+func reportEncodeSuccess(typeName string, size int) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	sink := *metricsSink.Load()
+	sink.IncCounter(MetricEncodeTotal, labelsForType(typeName))
+	sink.ObserveHist(MetricEncodedSizeBytes, float64(size), labelsForType(typeName))
+}
+
+// reportDecodeSuccess records a successful Parse of an IE of the given type.
+func reportDecodeSuccess(typeName string) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	(*metricsSink.Load()).IncCounter(MetricDecodeTotal, labelsForType(typeName))
+}
+
+// reportMalformed records a Parse failure caused by a truncated or otherwise
+// inconsistent TLV (e.g., declared Length longer than the remaining bytes).
+func reportMalformed(typeName string) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	(*metricsSink.Load()).IncCounter(MetricMalformedTotal, labelsForType(typeName))
+}
+
+// reportUnknownType records a Parse call that encountered an IE type code
+// this package doesn't recognize.
+func reportUnknownType(typeName string) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	(*metricsSink.Load()).IncCounter(MetricUnknownTypeTotal, labelsForType(typeName))
+}
+
+// reportValueRange records a decoded value falling outside the range allowed
+// for its IE type. Nothing in this package calls it.
+func reportValueRange(typeName string) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	(*metricsSink.Load()).IncCounter(MetricValueRangeTotal, labelsForType(typeName))
+}
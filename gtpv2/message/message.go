@@ -0,0 +1,146 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package message handles the GTPv2-C messages defined in 3GPP TS 29.274:
+// their common header and the IEs each message type carries.
+package message
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// Message type codes used by this package.
+const (
+	MsgTypeEchoRequest  uint8 = 0x01
+	MsgTypeEchoResponse uint8 = 0x02
+)
+
+const headerLen = 8
+
+// Header is the 8-byte GTPv2-C header used when no TEID is present (as is
+// the case for Echo Request/Response).
+type Header struct {
+	MessageType    uint8
+	Length         uint16
+	SequenceNumber uint32
+}
+
+// Message is a single GTPv2-C message: a Header plus the IEs it carries.
+type Message struct {
+	Header Header
+	IEs    []*ie.IE
+}
+
+// NewEchoRequest creates an Echo Request message.
+func NewEchoRequest(seq uint32, ies ...*ie.IE) *Message {
+	return &Message{Header: Header{MessageType: MsgTypeEchoRequest, SequenceNumber: seq}, IEs: ies}
+}
+
+// NewEchoResponse creates an Echo Response message.
+func NewEchoResponse(seq uint32, ies ...*ie.IE) *Message {
+	return &Message{Header: Header{MessageType: MsgTypeEchoResponse, SequenceNumber: seq}, IEs: ies}
+}
+
+// MarshalLen returns the number of bytes Marshal/MarshalTo will produce.
+func (m *Message) MarshalLen() (int, error) {
+	l := headerLen
+	for _, i := range m.IEs {
+		n, err := i.MarshalLen()
+		if err != nil {
+			return 0, err
+		}
+		l += n
+	}
+	return l, nil
+}
+
+// encodeInto writes the wire representation of m into b, returning the
+// number of bytes written. It is shared by Marshal and MarshalTo.
+func (m *Message) encodeInto(b []byte) (int, error) {
+	total, err := m.MarshalLen()
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < total {
+		return 0, fmt.Errorf("message: buffer of %d bytes too small for %d-byte message", len(b), total)
+	}
+
+	b[0] = 0x40 // Version 2, Piggybacking/TEID flags unset
+	b[1] = m.Header.MessageType
+	binary.BigEndian.PutUint16(b[2:4], uint16(total-4))
+	b[4] = byte(m.Header.SequenceNumber >> 16)
+	b[5] = byte(m.Header.SequenceNumber >> 8)
+	b[6] = byte(m.Header.SequenceNumber)
+	b[7] = 0 // spare
+
+	offset := headerLen
+	for _, i := range m.IEs {
+		n, err := i.MarshalTo(b[offset:])
+		if err != nil {
+			return 0, err
+		}
+		offset += n
+	}
+
+	return offset, nil
+}
+
+// Marshal encodes m into its wire representation.
+func (m *Message) Marshal() ([]byte, error) {
+	l, err := m.MarshalLen()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, l)
+	if _, err := m.encodeInto(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo encodes m into b, returning the number of bytes written. It
+// lets callers reuse a buffer instead of allocating a new one on every
+// call.
+func (m *Message) MarshalTo(b []byte) (int, error) {
+	return m.encodeInto(b)
+}
+
+// Parse decodes b into a Message.
+func Parse(b []byte) (*Message, error) {
+	if len(b) < headerLen {
+		return nil, fmt.Errorf("message: Parse: need at least %d bytes, got %d", headerLen, len(b))
+	}
+
+	msgType := b[1]
+	length := binary.BigEndian.Uint16(b[2:4])
+	seq := uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+
+	if int(length)+4 > len(b) {
+		return nil, fmt.Errorf("message: Parse: declared length %d exceeds remaining %d bytes", length, len(b)-4)
+	}
+
+	end := 4 + int(length)
+	offset := headerLen
+	var ies []*ie.IE
+	for offset < end {
+		parsed, err := ie.Parse(b[offset:end])
+		if err != nil {
+			return nil, fmt.Errorf("message: Parse: %w", err)
+		}
+		n, err := parsed.MarshalLen()
+		if err != nil {
+			return nil, err
+		}
+		ies = append(ies, parsed)
+		offset += n
+	}
+
+	return &Message{
+		Header: Header{MessageType: msgType, Length: length, SequenceNumber: seq},
+		IEs:    ies,
+	}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-2023 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// FuzzParse feeds arbitrary bytes to message.Parse and checks that it never
+// panics on malformed input.
+func FuzzParse(f *testing.F) {
+	f.Add([]byte{0x48, 0x01, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if _, err := message.Parse(b); err != nil {
+			return
+		}
+	})
+}
+
+// FuzzRoundTrip checks that Marshal -> Parse -> Marshal on any message that
+// successfully parses is idempotent.
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte{0x48, 0x01, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		parsed, err := message.Parse(b)
+		if err != nil {
+			return
+		}
+
+		first, err := parsed.Marshal()
+		if err != nil {
+			t.Fatalf("failed to marshal a successfully parsed message: %v", err)
+		}
+
+		reparsed, err := message.Parse(first)
+		if err != nil {
+			t.Fatalf("failed to re-parse a just-marshaled message: %v", err)
+		}
+
+		second, err := reparsed.Marshal()
+		if err != nil {
+			t.Fatalf("failed to re-marshal a re-parsed message: %v", err)
+		}
+
+		if !bytes.Equal(first, second) {
+			t.Errorf("Marshal->Parse->Marshal is not idempotent:\nfirst:  %x\nsecond: %x", first, second)
+		}
+	})
+}